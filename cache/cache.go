@@ -0,0 +1,23 @@
+package cache
+
+import (
+	"person-service/models"
+)
+
+// PersonCache holds persons keyed by their numeric ID.
+type PersonCache interface {
+	Get(id uint) (models.Person, bool)
+	Set(person models.Person)
+	Delete(id uint)
+}
+
+// NoopCache caches nothing; every lookup is a miss.
+type NoopCache struct{}
+
+func (NoopCache) Get(uint) (models.Person, bool) {
+	return models.Person{}, false
+}
+
+func (NoopCache) Set(models.Person) {}
+
+func (NoopCache) Delete(uint) {}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"container/list"
+	"person-service/models"
+	"sync"
+	"time"
+)
+
+// LRU is a size-bounded PersonCache whose entries expire after a fixed TTL.
+// When full, the least recently used entry is evicted.
+type LRU struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List
+	entries map[uint]*list.Element
+	now     func() time.Time
+}
+
+type lruEntry struct {
+	person    models.Person
+	expiresAt time.Time
+}
+
+func NewLRU(size int, ttl time.Duration) *LRU {
+	return &LRU{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[uint]*list.Element),
+		now:     time.Now,
+	}
+}
+
+func (c *LRU) Get(id uint) (models.Person, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[id]
+	if !ok {
+		return models.Person{}, false
+	}
+	entry := elem.Value.(*lruEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(elem)
+		return models.Person{}, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.person, true
+}
+
+func (c *LRU) Set(person models.Person) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &lruEntry{person: person, expiresAt: c.now().Add(c.ttl)}
+	if elem, ok := c.entries[person.ID]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[person.ID] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *LRU) Delete(id uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[id]; ok {
+		c.remove(elem)
+	}
+}
+
+// Len reports the number of cached entries, including expired ones not yet
+// evicted.
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *LRU) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*lruEntry).person.ID)
+}
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUGetReturnsCachedPerson(t *testing.T) {
+	c := NewLRU(10, time.Minute)
+	c.Set(models.Person{ID: 1, Name: "Jane"})
+
+	person, ok := c.Get(1)
+
+	assert.True(t, ok)
+	assert.Equal(t, "Jane", person.Name)
+	_, ok = c.Get(2)
+	assert.False(t, ok)
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU(2, time.Minute)
+	c.Set(models.Person{ID: 1})
+	c.Set(models.Person{ID: 2})
+	c.Get(1)
+	c.Set(models.Person{ID: 3})
+
+	_, ok := c.Get(2)
+	assert.False(t, ok)
+	_, ok = c.Get(1)
+	assert.True(t, ok)
+	_, ok = c.Get(3)
+	assert.True(t, ok)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestLRUExpiresEntries(t *testing.T) {
+	now := time.Now()
+	c := NewLRU(10, time.Minute)
+	c.now = func() time.Time { return now }
+	c.Set(models.Person{ID: 1})
+
+	now = now.Add(time.Minute)
+
+	_, ok := c.Get(1)
+	assert.False(t, ok)
+	assert.Zero(t, c.Len())
+}
+
+func TestLRUDelete(t *testing.T) {
+	c := NewLRU(10, time.Minute)
+	c.Set(models.Person{ID: 1})
+
+	c.Delete(1)
+	c.Delete(2)
+
+	_, ok := c.Get(1)
+	assert.False(t, ok)
+}
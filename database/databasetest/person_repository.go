@@ -0,0 +1,95 @@
+// Package databasetest provides test doubles for the database package.
+package databasetest
+
+import (
+	"context"
+	"fmt"
+	"person-service/database"
+	"person-service/models"
+
+	"github.com/google/uuid"
+)
+
+// PersonRepository is a database.PersonRepository whose methods call the
+// matching func field. A method whose field is nil returns an error, so a
+// test fails loudly when the handler makes a call it did not expect.
+type PersonRepository struct {
+	CreateFunc              func(ctx context.Context, person *models.Person) error
+	GetByIDFunc             func(ctx context.Context, id uint, opts database.GetOptions) (models.Person, error)
+	GetByExternalIDFunc     func(ctx context.Context, externalID uuid.UUID, opts database.GetOptions) (models.Person, error)
+	GetByIdempotencyKeyFunc func(ctx context.Context, key string) (models.Person, error)
+	UpdateFunc              func(ctx context.Context, person *models.Person, changes map[string]interface{}) error
+	AnonymizeFunc           func(ctx context.Context, person *models.Person) error
+	DeleteFunc              func(ctx context.Context, id uint) (models.Person, error)
+	DeleteMatchingFunc      func(ctx context.Context, filter database.PersonFilter) ([]models.Person, error)
+	ListFunc                func(ctx context.Context, opts database.ListOptions) ([]models.Person, int64, error)
+}
+
+var _ database.PersonRepository = (*PersonRepository)(nil)
+
+func (r *PersonRepository) Create(ctx context.Context, person *models.Person) error {
+	if r.CreateFunc == nil {
+		return unexpected("Create")
+	}
+	return r.CreateFunc(ctx, person)
+}
+
+func (r *PersonRepository) GetByID(ctx context.Context, id uint, opts database.GetOptions) (models.Person, error) {
+	if r.GetByIDFunc == nil {
+		return models.Person{}, unexpected("GetByID")
+	}
+	return r.GetByIDFunc(ctx, id, opts)
+}
+
+func (r *PersonRepository) GetByExternalID(ctx context.Context, externalID uuid.UUID, opts database.GetOptions) (models.Person, error) {
+	if r.GetByExternalIDFunc == nil {
+		return models.Person{}, unexpected("GetByExternalID")
+	}
+	return r.GetByExternalIDFunc(ctx, externalID, opts)
+}
+
+func (r *PersonRepository) GetByIdempotencyKey(ctx context.Context, key string) (models.Person, error) {
+	if r.GetByIdempotencyKeyFunc == nil {
+		return models.Person{}, unexpected("GetByIdempotencyKey")
+	}
+	return r.GetByIdempotencyKeyFunc(ctx, key)
+}
+
+func (r *PersonRepository) Update(ctx context.Context, person *models.Person, changes map[string]interface{}) error {
+	if r.UpdateFunc == nil {
+		return unexpected("Update")
+	}
+	return r.UpdateFunc(ctx, person, changes)
+}
+
+func (r *PersonRepository) Anonymize(ctx context.Context, person *models.Person) error {
+	if r.AnonymizeFunc == nil {
+		return unexpected("Anonymize")
+	}
+	return r.AnonymizeFunc(ctx, person)
+}
+
+func (r *PersonRepository) Delete(ctx context.Context, id uint) (models.Person, error) {
+	if r.DeleteFunc == nil {
+		return models.Person{}, unexpected("Delete")
+	}
+	return r.DeleteFunc(ctx, id)
+}
+
+func (r *PersonRepository) DeleteMatching(ctx context.Context, filter database.PersonFilter) ([]models.Person, error) {
+	if r.DeleteMatchingFunc == nil {
+		return nil, unexpected("DeleteMatching")
+	}
+	return r.DeleteMatchingFunc(ctx, filter)
+}
+
+func (r *PersonRepository) List(ctx context.Context, opts database.ListOptions) ([]models.Person, int64, error) {
+	if r.ListFunc == nil {
+		return nil, 0, unexpected("List")
+	}
+	return r.ListFunc(ctx, opts)
+}
+
+func unexpected(method string) error {
+	return fmt.Errorf("databasetest: unexpected call to %s", method)
+}
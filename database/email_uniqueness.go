@@ -0,0 +1,40 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"person-service/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrEmailUniquenessMismatch reports that the email index of the people
+// table does not match the configured email uniqueness.
+var ErrEmailUniquenessMismatch = errors.New("email index does not match UNIQUE_EMAIL")
+
+// emailUniquenessHelp points operators hitting ErrEmailUniquenessMismatch to
+// the README section with the statements that switch the index.
+const emailUniquenessHelp = `switch the index as described under "Switching email uniqueness" in README.md (#switching-email-uniqueness)`
+
+// CheckEmailUniqueness returns ErrEmailUniquenessMismatch unless the unique
+// models.EmailIndex, created by the migrations, exists and is valid exactly
+// when unique is set. Switching is a one-off schema change an operator makes
+// before restarting every instance with the new setting, as the README
+// describes; were each instance to apply its own setting on startup,
+// instances configured differently would flip the index back and forth.
+func CheckEmailUniqueness(ctx context.Context, db *gorm.DB, unique bool) error {
+	var indexes int64
+	err := db.WithContext(ctx).Raw(
+		"SELECT count(*) FROM pg_index WHERE indexrelid = to_regclass(?) AND indisunique AND indisvalid",
+		models.EmailIndex,
+	).Scan(&indexes).Error
+	if err != nil {
+		return err
+	}
+
+	if exists := indexes > 0; exists != unique {
+		return fmt.Errorf("%w: unique index %s exists: %t, UNIQUE_EMAIL: %t; %s", ErrEmailUniquenessMismatch, models.EmailIndex, exists, unique, emailUniquenessHelp)
+	}
+	return nil
+}
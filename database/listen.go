@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+)
+
+// PersonChangesChannel is the channel the people table's trigger notifies
+// with a JSON object holding the event, id, external_id and tenant_id of
+// each changed person.
+const PersonChangesChannel = "person_changes"
+
+const listenRetryDelay = 5 * time.Second
+
+// Listen LISTENs on channel over a connection of its own and calls handle
+// with the payload of each notification until ctx is done. A lost
+// connection is re-established after a delay; notifications sent in the
+// meantime are missed.
+func Listen(ctx context.Context, db *gorm.DB, channel string, handle func(payload string)) {
+	for {
+		err := listenOnce(ctx, db, channel, handle)
+		if ctx.Err() != nil {
+			return
+		}
+		slog.ErrorContext(ctx, "Lost database notification listener", "channel", channel, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+func listenOnce(ctx context.Context, db *gorm.DB, channel string, handle func(payload string)) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var listenErr error
+	conn.Raw(func(driverConn any) error {
+		pgxConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			listenErr = fmt.Errorf("unexpected driver connection %T", driverConn)
+			return nil
+		}
+		listenErr = waitForNotifications(ctx, pgxConn.Conn(), channel, handle)
+		// The connection is still subscribed, so it must not go back to
+		// the pool.
+		return driver.ErrBadConn
+	})
+	return listenErr
+}
+
+func waitForNotifications(ctx context.Context, conn *pgx.Conn, channel string, handle func(payload string)) error {
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return err
+	}
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		handle(notification.Payload)
+	}
+}
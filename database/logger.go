@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// slogLogger sends GORM's logging through slog, so database records carry
+// the request ID like every other record.
+type slogLogger struct {
+	level         logger.LogLevel
+	slowThreshold time.Duration
+}
+
+// NewLogger returns a GORM logger that logs failed queries at error level
+// and queries running longer than slowThreshold at warn level, with their
+// SQL and duration. A slowThreshold of 0 disables slow-query logging. Every
+// query is logged at debug level.
+func NewLogger(slowThreshold time.Duration) logger.Interface {
+	return slogLogger{level: logger.Warn, slowThreshold: slowThreshold}
+}
+
+func (l slogLogger) LogMode(level logger.LogLevel) logger.Interface {
+	l.level = level
+	return l
+}
+
+func (l slogLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Info {
+		slog.InfoContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l slogLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Warn {
+		slog.WarnContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l slogLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Error {
+		slog.ErrorContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l slogLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= logger.Error:
+		sql, rows := fc()
+		slog.ErrorContext(ctx, "Database query failed", "sql", sql, "rows", rows, "duration", elapsed.String(), "error", err)
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= logger.Warn:
+		sql, rows := fc()
+		slog.WarnContext(ctx, "Slow database query", "sql", sql, "rows", rows, "duration", elapsed.String(), "threshold", l.slowThreshold.String())
+	case slog.Default().Enabled(ctx, slog.LevelDebug):
+		sql, rows := fc()
+		slog.DebugContext(ctx, "Database query", "sql", sql, "rows", rows, "duration", elapsed.String())
+	}
+}
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"person-service/logger"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func captureLogs(t *testing.T, level slog.Level) *bytes.Buffer {
+	t.Helper()
+
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logger.NewWithWriter(&logs, level))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &logs
+}
+
+func traceQuery(l gormlogger.Interface, elapsed time.Duration, err error) {
+	l.Trace(context.Background(), time.Now().Add(-elapsed), func() (string, int64) {
+		return "SELECT * FROM people", 3
+	}, err)
+}
+
+func TestLoggerLogsSlowQueries(t *testing.T) {
+	logs := captureLogs(t, slog.LevelInfo)
+
+	traceQuery(NewLogger(100*time.Millisecond), 150*time.Millisecond, nil)
+
+	assert.Contains(t, logs.String(), `"level":"WARN"`)
+	assert.Contains(t, logs.String(), `"msg":"Slow database query"`)
+	assert.Contains(t, logs.String(), `"sql":"SELECT * FROM people"`)
+}
+
+func TestLoggerIgnoresFastQueries(t *testing.T) {
+	logs := captureLogs(t, slog.LevelInfo)
+
+	traceQuery(NewLogger(100*time.Millisecond), 10*time.Millisecond, nil)
+	traceQuery(NewLogger(0), time.Hour, nil)
+
+	assert.Empty(t, logs.String())
+}
+
+func TestLoggerLogsFailedQueries(t *testing.T) {
+	logs := captureLogs(t, slog.LevelInfo)
+
+	traceQuery(NewLogger(100*time.Millisecond), time.Millisecond, errors.New("connection reset"))
+	traceQuery(NewLogger(100*time.Millisecond), time.Millisecond, gorm.ErrRecordNotFound)
+
+	assert.Equal(t, 1, bytes.Count(logs.Bytes(), []byte(`"msg":"Database query failed"`)))
+	assert.Contains(t, logs.String(), `"error":"connection reset"`)
+}
@@ -0,0 +1,212 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"person-service/models"
+	"sync"
+
+	"github.com/golang-migrate/migrate/v4"
+	migratepostgres "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+const undefinedTableCode = "42P01"
+
+// migrationLockID keys the advisory lock that serializes migration runs
+// across instances. It must differ from golang-migrate's own lock key.
+const migrationLockID int64 = 0x7065_7273_6f6e // "person"
+
+// ErrNotMigrated reports that the database schema lags behind the embedded
+// migrations.
+var ErrNotMigrated = errors.New("database schema is not migrated")
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrate applies every pending migration from the embedded migrations
+// directory. Applied versions are tracked in the schema_migrations table.
+func Migrate(db *gorm.DB) error {
+	return runMigrations(db, (*migrate.Migrate).Up)
+}
+
+// MigrateDown reverts every applied migration, dropping the schema.
+func MigrateDown(db *gorm.DB) error {
+	return runMigrations(db, (*migrate.Migrate).Down)
+}
+
+// CheckMigrated returns ErrNotMigrated until every embedded migration has
+// been applied cleanly. A newer schema than this binary knows is accepted,
+// so instances of the previous release stay ready while the next one
+// migrates.
+func CheckMigrated(ctx context.Context, db *gorm.DB) error {
+	latest, err := latestMigration()
+	if err != nil {
+		return err
+	}
+
+	var state struct {
+		Version uint
+		Dirty   bool
+	}
+	err = db.WithContext(ctx).Raw("SELECT version, dirty FROM schema_migrations").Scan(&state).Error
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == undefinedTableCode {
+		return fmt.Errorf("%w: no migrations applied", ErrNotMigrated)
+	}
+	if err != nil {
+		return err
+	}
+
+	if state.Version < latest || (state.Version == latest && state.Dirty) {
+		return fmt.Errorf("%w: at version %d (dirty: %t), want %d", ErrNotMigrated, state.Version, state.Dirty, latest)
+	}
+	return nil
+}
+
+// searchNameBackfillBatch is how many persons BackfillSearchNames fills per
+// transaction.
+const searchNameBackfillBatch = 500
+
+// BackfillSearchNames fills search_name for persons stored before the column
+// existed, soft-deleted ones included, and returns how many it filled. Rows
+// that already have one are skipped, so running it on every start is cheap.
+// Each filled row is an update and is announced on the change feed.
+func BackfillSearchNames(ctx context.Context, db *gorm.DB) (int, error) {
+	db = db.WithContext(ctx)
+
+	filled := 0
+	var lastID uint
+	for {
+		var rows []struct {
+			ID   uint
+			Name string
+		}
+		err := db.Model(&models.Person{}).Unscoped().Select("id", "name").
+			Where("search_name = '' AND id > ?", lastID).
+			Order("id").Limit(searchNameBackfillBatch).Find(&rows).Error
+		if err != nil {
+			return filled, err
+		}
+		if len(rows) == 0 {
+			return filled, nil
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			for _, row := range rows {
+				err := tx.Model(&models.Person{}).Unscoped().Where("id = ?", row.ID).
+					UpdateColumn("search_name", models.SearchName(row.Name)).Error
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return filled, fmt.Errorf("backfill search names: %w", err)
+		}
+		filled += len(rows)
+		lastID = rows[len(rows)-1].ID
+	}
+}
+
+// latestMigration is the highest embedded migration version.
+var latestMigration = sync.OnceValues(func() (uint, error) {
+	source, err := iofs.New(migrationFiles, "migrations")
+	if err != nil {
+		return 0, err
+	}
+	defer source.Close()
+
+	version, err := source.First()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		next, err := source.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+})
+
+// runMigrations borrows a single connection from the pool for the migration
+// run so that closing the migrator does not close the application's pool.
+//
+// The run holds an advisory lock on that connection, so instances starting
+// together migrate one at a time and the others wait for it. golang-migrate
+// locks as well, but gives up after 15 seconds, which a long migration on a
+// big table easily exceeds.
+func runMigrations(db *gorm.DB, run func(*migrate.Migrate) error) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		conn.Close()
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	// Closing the driver hands conn back to the pool, so the lock must be
+	// released first on every path.
+	unlock := func() { releaseMigrationLock(ctx, conn) }
+
+	driver, err := migratepostgres.WithConnection(ctx, conn, &migratepostgres.Config{})
+	if err != nil {
+		unlock()
+		conn.Close()
+		return fmt.Errorf("prepare migration driver: %w", err)
+	}
+
+	source, err := iofs.New(migrationFiles, "migrations")
+	if err != nil {
+		unlock()
+		driver.Close()
+		return fmt.Errorf("load migrations: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, "postgres", driver)
+	if err != nil {
+		source.Close()
+		unlock()
+		driver.Close()
+		return err
+	}
+	defer m.Close()
+	defer unlock()
+
+	if err := run(m); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("run migrations: %w", err)
+	}
+	return nil
+}
+
+// releaseMigrationLock unlocks the migration lock held by conn. When that
+// fails, the connection is discarded rather than returned to the pool, where
+// it would keep holding the lock.
+func releaseMigrationLock(ctx context.Context, conn *sql.Conn) {
+	_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationLockID)
+	if err == nil {
+		return
+	}
+	slog.WarnContext(ctx, "Failed to release migration lock", "error", err)
+	conn.Raw(func(any) error { return driver.ErrBadConn })
+}
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS people (
+    id                  bigserial PRIMARY KEY,
+    external_id         uuid NOT NULL,
+    name                text NOT NULL,
+    email               text NOT NULL,
+    phone               varchar(16),
+    address_street      varchar(200),
+    address_city        varchar(100),
+    address_region      varchar(100),
+    address_postal_code varchar(20),
+    address_country     varchar(2),
+    date_of_birth       timestamptz NOT NULL,
+    created_at          timestamptz,
+    updated_at          timestamptz,
+    deleted_at          timestamptz,
+    idempotency_key     varchar(255),
+    version             bigint NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_people_deleted_at ON people (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_people_external_id ON people (external_id) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_people_email ON people (email) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_people_idempotency_key ON people (idempotency_key) WHERE deleted_at IS NULL;
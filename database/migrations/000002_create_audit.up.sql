@@ -0,0 +1,10 @@
+CREATE TABLE audit (
+    id         bigserial PRIMARY KEY,
+    person_id  bigint NOT NULL REFERENCES people (id) ON DELETE CASCADE,
+    action     varchar(16) NOT NULL,
+    before     jsonb,
+    after      jsonb,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_audit_person_id ON audit (person_id, id);
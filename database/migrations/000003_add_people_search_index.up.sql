@@ -0,0 +1 @@
+CREATE INDEX idx_people_search ON people USING GIN (to_tsvector('simple', name || ' ' || email));
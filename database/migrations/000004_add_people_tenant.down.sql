@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_people_external_id;
+DROP INDEX IF EXISTS idx_people_email;
+DROP INDEX IF EXISTS idx_people_idempotency_key;
+CREATE UNIQUE INDEX idx_people_external_id ON people (external_id) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_people_email ON people (email) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_people_idempotency_key ON people (idempotency_key) WHERE deleted_at IS NULL;
+
+ALTER TABLE people DROP COLUMN IF EXISTS tenant_id;
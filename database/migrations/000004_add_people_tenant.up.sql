@@ -0,0 +1,10 @@
+-- Rows that predate multi-tenancy belong to the nil tenant.
+ALTER TABLE people ADD COLUMN IF NOT EXISTS tenant_id uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000';
+ALTER TABLE people ALTER COLUMN tenant_id DROP DEFAULT;
+
+DROP INDEX IF EXISTS idx_people_external_id;
+DROP INDEX IF EXISTS idx_people_email;
+DROP INDEX IF EXISTS idx_people_idempotency_key;
+CREATE UNIQUE INDEX idx_people_external_id ON people (tenant_id, external_id) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_people_email ON people (tenant_id, email) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_people_idempotency_key ON people (tenant_id, idempotency_key) WHERE deleted_at IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE people ALTER COLUMN date_of_birth TYPE timestamptz USING date_of_birth::timestamp AT TIME ZONE 'UTC';
@@ -0,0 +1,2 @@
+-- Keep the UTC calendar date of existing timestamps.
+ALTER TABLE people ALTER COLUMN date_of_birth TYPE date USING (date_of_birth AT TIME ZONE 'UTC')::date;
@@ -0,0 +1 @@
+CREATE INDEX idx_people_email_domain ON people (tenant_id, split_part(email, '@', 2)) WHERE deleted_at IS NULL;
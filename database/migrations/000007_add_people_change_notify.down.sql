@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS people_notify_change ON people;
+DROP FUNCTION IF EXISTS notify_person_change();
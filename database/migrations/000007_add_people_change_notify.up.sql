@@ -0,0 +1,35 @@
+-- Every committed change to a person is announced on the person_changes
+-- channel. Soft deletes are updates of deleted_at, reported as deletes.
+CREATE OR REPLACE FUNCTION notify_person_change() RETURNS trigger AS $$
+DECLARE
+    event text;
+    person people%ROWTYPE;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        event := 'person.created';
+        person := NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        event := 'person.deleted';
+        person := OLD;
+    ELSIF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+        event := 'person.deleted';
+        person := NEW;
+    ELSE
+        event := 'person.updated';
+        person := NEW;
+    END IF;
+
+    PERFORM pg_notify('person_changes', json_build_object(
+        'event', event,
+        'id', person.id,
+        'external_id', person.external_id,
+        'tenant_id', person.tenant_id
+    )::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS people_notify_change ON people;
+CREATE TRIGGER people_notify_change
+    AFTER INSERT OR UPDATE OR DELETE ON people
+    FOR EACH ROW EXECUTE FUNCTION notify_person_change();
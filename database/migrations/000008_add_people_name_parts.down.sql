@@ -0,0 +1,4 @@
+ALTER TABLE people
+    DROP COLUMN IF EXISTS first_name,
+    DROP COLUMN IF EXISTS middle_name,
+    DROP COLUMN IF EXISTS last_name;
@@ -0,0 +1,4 @@
+ALTER TABLE people
+    ADD COLUMN IF NOT EXISTS first_name  varchar(100),
+    ADD COLUMN IF NOT EXISTS middle_name varchar(100),
+    ADD COLUMN IF NOT EXISTS last_name   varchar(100);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_people_search;
+CREATE INDEX idx_people_search ON people USING GIN (to_tsvector('simple', name || ' ' || email));
+
+ALTER TABLE people DROP COLUMN IF EXISTS search_name;
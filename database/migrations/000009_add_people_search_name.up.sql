@@ -0,0 +1,7 @@
+-- search_name holds the lowercased, unaccented name maintained by the
+-- application; rows stored before this migration are filled in by
+-- database.BackfillSearchNames on startup.
+ALTER TABLE people ADD COLUMN IF NOT EXISTS search_name text NOT NULL DEFAULT '';
+
+DROP INDEX IF EXISTS idx_people_search;
+CREATE INDEX idx_people_search ON people USING GIN (to_tsvector('simple', search_name || ' ' || email));
@@ -0,0 +1 @@
+ALTER TABLE people ADD COLUMN IF NOT EXISTS active boolean NOT NULL DEFAULT true;
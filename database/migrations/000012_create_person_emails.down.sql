@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS people_sync_primary_email_update ON people;
+DROP TRIGGER IF EXISTS people_sync_primary_email_insert ON people;
+DROP FUNCTION IF EXISTS sync_primary_email();
+DROP TABLE IF EXISTS person_emails;
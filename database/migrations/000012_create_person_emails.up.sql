@@ -0,0 +1,36 @@
+-- A person's email addresses. The primary one mirrors people.email and is
+-- maintained by the triggers below, so every way of writing a person keeps
+-- exactly one primary address; the others are added alongside it.
+CREATE TABLE person_emails (
+    id         bigserial PRIMARY KEY,
+    person_id  bigint NOT NULL REFERENCES people (id) ON DELETE CASCADE,
+    email      text NOT NULL,
+    is_primary boolean NOT NULL DEFAULT false,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX idx_person_emails_email ON person_emails (person_id, email);
+CREATE UNIQUE INDEX idx_person_emails_primary ON person_emails (person_id) WHERE is_primary;
+
+INSERT INTO person_emails (person_id, email, is_primary)
+SELECT id, email, true FROM people;
+
+CREATE OR REPLACE FUNCTION sync_primary_email() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO person_emails (person_id, email, is_primary) VALUES (NEW.id, NEW.email, true);
+    ELSE
+        -- The new primary may already be one of the other addresses.
+        DELETE FROM person_emails WHERE person_id = NEW.id AND email = NEW.email AND NOT is_primary;
+        UPDATE person_emails SET email = NEW.email WHERE person_id = NEW.id AND is_primary;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER people_sync_primary_email_insert
+    AFTER INSERT ON people
+    FOR EACH ROW EXECUTE FUNCTION sync_primary_email();
+CREATE TRIGGER people_sync_primary_email_update
+    AFTER UPDATE OF email ON people
+    FOR EACH ROW WHEN (OLD.email IS DISTINCT FROM NEW.email) EXECUTE FUNCTION sync_primary_email();
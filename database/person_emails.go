@@ -0,0 +1,57 @@
+package database
+
+import (
+	"fmt"
+	"person-service/models"
+
+	"gorm.io/gorm"
+)
+
+// TakenEmails returns which of emails a live person of the tenant other than
+// exceptID has, as its email or one of its other addresses. Primary emails
+// are mirrored into person_emails, so a single join covers both. Pass 0 as
+// exceptID when checking for a person that is not stored yet.
+func TakenEmails(db *gorm.DB, emails []string, exceptID uint) ([]string, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+	var taken []string
+	err := db.Model(&models.Person{}).
+		Joins("JOIN person_emails ON person_emails.person_id = people.id").
+		Where("person_emails.email IN ? AND people.id <> ?", emails, exceptID).
+		Distinct().Pluck("person_emails.email", &taken).Error
+	return taken, err
+}
+
+// CheckEmailsFree is TakenEmails for writes: it returns a
+// *UniqueViolationError on models.EmailIndex when any of emails is taken,
+// so callers report it like a clash on the primary email. The unique index
+// only covers primary emails; the other addresses rely on this check.
+func CheckEmailsFree(db *gorm.DB, emails []string, exceptID uint) error {
+	taken, err := TakenEmails(db, emails, exceptID)
+	if err != nil {
+		return err
+	}
+	if len(taken) > 0 {
+		return &UniqueViolationError{Constraint: models.EmailIndex, Err: fmt.Errorf("email %s is taken", taken[0])}
+	}
+	return nil
+}
+
+// ReplaceEmails replaces the addresses of the person besides its primary
+// email with emails. The primary row is left to the triggers that keep it in
+// step with people.email.
+func ReplaceEmails(tx *gorm.DB, personID uint, emails []models.PersonEmail) error {
+	if err := tx.Where("person_id = ? AND NOT is_primary", personID).Delete(&models.PersonEmail{}).Error; err != nil {
+		return err
+	}
+	if len(emails) == 0 {
+		return nil
+	}
+
+	rows := make([]models.PersonEmail, len(emails))
+	for i, e := range emails {
+		rows[i] = models.PersonEmail{PersonID: personID, Email: e.Email}
+	}
+	return tx.Create(&rows).Error
+}
@@ -0,0 +1,396 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"person-service/models"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
+)
+
+var (
+	ErrPersonNotFound  = errors.New("person not found")
+	ErrVersionConflict = errors.New("person was modified concurrently")
+)
+
+// PersonRepository stores persons. Every method is scoped to the tenant of
+// its context and bounded by the context's deadline. Writes record their
+// audit entries in the same transaction; unique violations are returned as
+// *UniqueViolationError; with unique emails, that includes an address
+// another person already has besides its primary email.
+type PersonRepository interface {
+	Create(ctx context.Context, person *models.Person) error
+	GetByID(ctx context.Context, id uint, opts GetOptions) (models.Person, error)
+	GetByExternalID(ctx context.Context, externalID uuid.UUID, opts GetOptions) (models.Person, error)
+	// GetByIdempotencyKey always reads from the primary, since it is used
+	// right after a create that collided with the key.
+	GetByIdempotencyKey(ctx context.Context, key string) (models.Person, error)
+	// Update applies changes to person if its stored version still matches
+	// person.Version, bumping the version, and returns ErrVersionConflict
+	// otherwise. Under models.EmailsChange changes may hold the addresses
+	// replacing the person's others. On success person holds the updated
+	// values.
+	Update(ctx context.Context, person *models.Person, changes map[string]interface{}) error
+	// Anonymize replaces the person's personal data with placeholders,
+	// keeping the row and its external_id, and records the time in
+	// AnonymizedAt. The snapshots in the person's earlier audit entries are
+	// cleared. Like Update it checks person.Version and returns
+	// ErrVersionConflict on a mismatch; on success person holds the new
+	// values.
+	Anonymize(ctx context.Context, person *models.Person) error
+	// Delete soft-deletes the person and returns it as it was.
+	Delete(ctx context.Context, id uint) (models.Person, error)
+	// DeleteMatching soft-deletes every person matching filter in one
+	// transaction and returns them as they were.
+	DeleteMatching(ctx context.Context, filter PersonFilter) ([]models.Person, error)
+	// List returns one page of persons matching opts and the total number of
+	// matches.
+	List(ctx context.Context, opts ListOptions) ([]models.Person, int64, error)
+}
+
+type GetOptions struct {
+	IncludeDeleted bool
+	// FromPrimary bypasses the read replicas, for reads that must see the
+	// latest write.
+	FromPrimary bool
+	// IncludeInactive also finds deactivated persons, which writes need.
+	IncludeInactive bool
+}
+
+type ListOptions struct {
+	Filter PersonFilter
+	// Order is an ORDER BY clause; callers must build it from whitelisted
+	// columns.
+	Order  string
+	Limit  int
+	Offset int
+	// WindowTotal computes the total with COUNT(*) OVER() in the page query
+	// instead of a separate COUNT, saving a round-trip. Only a page past the
+	// last one still needs the separate count.
+	WindowTotal bool
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike escapes the LIKE wildcards in s so it matches literally.
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// emailDomain must match the expression of the idx_people_email_domain
+// index for Postgres to use it.
+const emailDomain = "split_part(email, '@', 2)"
+
+// PersonFilter holds the optional list filters; multiple filters are ANDed.
+type PersonFilter struct {
+	// Name matches a substring of the name, ignoring case and accents.
+	Name       string
+	Email      string
+	BornAfter  *models.Date
+	BornBefore *models.Date
+	// EmailDomain matches emails at exactly this domain, not its subdomains.
+	EmailDomain string
+	ExcludeID   uint
+	// IncludeInactive also matches deactivated persons.
+	IncludeInactive bool
+}
+
+// Scope applies the filter as GORM conditions, for use with db.Scopes.
+func (f PersonFilter) Scope(db *gorm.DB) *gorm.DB {
+	if f.Name != "" {
+		db = db.Where("search_name LIKE ?", "%"+EscapeLike(models.SearchName(f.Name))+"%")
+	}
+	if f.Email != "" {
+		db = db.Where("email = ?", f.Email)
+	}
+	if f.BornAfter != nil {
+		db = db.Where("date_of_birth > ?", *f.BornAfter)
+	}
+	if f.BornBefore != nil {
+		db = db.Where("date_of_birth < ?", *f.BornBefore)
+	}
+	if f.EmailDomain != "" {
+		db = db.Where(emailDomain+" = ?", f.EmailDomain)
+	}
+	if f.ExcludeID != 0 {
+		db = db.Where("id <> ?", f.ExcludeID)
+	}
+	if !f.IncludeInactive {
+		db = db.Where("active")
+	}
+	return db
+}
+
+// PreloadEmails loads each person's addresses besides the primary email
+// into Person.Emails, for use with db.Scopes.
+func PreloadEmails(db *gorm.DB) *gorm.DB {
+	return db.Preload("Emails", "NOT is_primary", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	})
+}
+
+type gormPersonRepository struct {
+	db          *gorm.DB
+	uniqueEmail bool
+}
+
+// NewPersonRepository returns a PersonRepository backed by db. uniqueEmail
+// must match the email index, as CheckEmailUniqueness verifies; when set,
+// writes also check the addresses the index does not cover.
+func NewPersonRepository(db *gorm.DB, uniqueEmail bool) PersonRepository {
+	return gormPersonRepository{db: db, uniqueEmail: uniqueEmail}
+}
+
+func (r gormPersonRepository) Create(ctx context.Context, person *models.Person) error {
+	return Transaction(Conn(ctx, r.db), func(tx *gorm.DB) error {
+		if r.uniqueEmail {
+			if err := CheckEmailsFree(tx, person.EmailAddresses(), 0); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(person).Error; err != nil {
+			return err
+		}
+		return RecordAudit(tx, models.AuditCreate, nil, person)
+	})
+}
+
+func (r gormPersonRepository) GetByID(ctx context.Context, id uint, opts GetOptions) (models.Person, error) {
+	var person models.Person
+	err := r.query(ctx, opts).First(&person, id).Error
+	return person, notFound(err)
+}
+
+func (r gormPersonRepository) GetByExternalID(ctx context.Context, externalID uuid.UUID, opts GetOptions) (models.Person, error) {
+	var person models.Person
+	err := r.query(ctx, opts).Where("external_id = ?", externalID).First(&person).Error
+	return person, notFound(err)
+}
+
+func (r gormPersonRepository) GetByIdempotencyKey(ctx context.Context, key string) (models.Person, error) {
+	var person models.Person
+	err := r.query(ctx, GetOptions{FromPrimary: true, IncludeInactive: true}).Where("idempotency_key = ?", key).First(&person).Error
+	return person, notFound(err)
+}
+
+func (r gormPersonRepository) Update(ctx context.Context, person *models.Person, changes map[string]interface{}) error {
+	before := *person
+	expected := person.Version
+	emails, replaceEmails := changes[models.EmailsChange].([]models.PersonEmail)
+	delete(changes, models.EmailsChange)
+	changes["version"] = expected + 1
+
+	var addresses []string
+	if email, ok := changes["email"].(string); ok {
+		addresses = append(addresses, email)
+	}
+	for _, e := range emails {
+		addresses = append(addresses, e.Email)
+	}
+
+	return Transaction(Conn(ctx, r.db), func(tx *gorm.DB) error {
+		if r.uniqueEmail {
+			if err := CheckEmailsFree(tx, addresses, person.ID); err != nil {
+				return err
+			}
+		}
+		result := tx.Model(person).Omit(clause.Associations).Where("version = ?", expected).Updates(changes)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return ErrVersionConflict
+		}
+		if replaceEmails {
+			if err := ReplaceEmails(tx, person.ID, emails); err != nil {
+				return err
+			}
+		}
+		if _, ok := changes["email"]; ok || replaceEmails {
+			// A new primary email drops the same address from the others.
+			if err := reloadEmails(tx, person); err != nil {
+				return err
+			}
+		}
+		return RecordAudit(tx, models.AuditUpdate, &before, person)
+	})
+}
+
+func (r gormPersonRepository) Anonymize(ctx context.Context, person *models.Person) error {
+	expected := person.Version
+	changes := map[string]interface{}{
+		"name":                models.RedactedName,
+		"first_name":          "",
+		"middle_name":         "",
+		"last_name":           "",
+		"email":               models.AnonymizedEmail(person.ExternalID),
+		"phone":               "",
+		"address_street":      "",
+		"address_city":        "",
+		"address_region":      "",
+		"address_postal_code": "",
+		"address_country":     "",
+		"date_of_birth":       models.AnonymizedDateOfBirth,
+		"anonymized_at":       time.Now(),
+		"version":             expected + 1,
+	}
+
+	return Transaction(Conn(ctx, r.db), func(tx *gorm.DB) error {
+		result := tx.Model(person).Omit(clause.Associations).Where("version = ?", expected).Updates(changes)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return ErrVersionConflict
+		}
+
+		err := tx.Where("person_id = ? AND NOT is_primary", person.ID).Delete(&models.PersonEmail{}).Error
+		if err != nil {
+			return err
+		}
+		person.Emails = nil
+
+		err = tx.Model(&models.AuditEntry{}).Where("person_id = ?", person.ID).
+			Updates(map[string]interface{}{"before": nil, "after": nil}).Error
+		if err != nil {
+			return err
+		}
+		return RecordAudit(tx, models.AuditAnonymize, nil, person)
+	})
+}
+
+func (r gormPersonRepository) Delete(ctx context.Context, id uint) (models.Person, error) {
+	var person models.Person
+	err := Transaction(Conn(ctx, r.db), func(tx *gorm.DB) error {
+		if err := tx.First(&person, id).Error; err != nil {
+			return notFound(err)
+		}
+
+		before := person
+		result := tx.Delete(&person)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPersonNotFound
+		}
+		return RecordAudit(tx, models.AuditDelete, &before, nil)
+	})
+	return person, err
+}
+
+func (r gormPersonRepository) DeleteMatching(ctx context.Context, filter PersonFilter) ([]models.Person, error) {
+	var persons []models.Person
+	err := Transaction(Conn(ctx, r.db), func(tx *gorm.DB) error {
+		// Locking the matches keeps the audit in step with what the delete
+		// removes.
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Scopes(filter.Scope).Order("id").Find(&persons).Error
+		if err != nil || len(persons) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(persons))
+		for i, person := range persons {
+			ids[i] = person.ID
+		}
+		if err := tx.Delete(&models.Person{}, ids).Error; err != nil {
+			return err
+		}
+		for i := range persons {
+			if err := RecordAudit(tx, models.AuditDelete, &persons[i], nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return persons, nil
+}
+
+func (r gormPersonRepository) List(ctx context.Context, opts ListOptions) ([]models.Person, int64, error) {
+	db := Conn(ctx, r.db)
+
+	if opts.WindowTotal {
+		persons, total, err := listWithWindowTotal(db, opts)
+		if err != nil || len(persons) > 0 || opts.Offset == 0 {
+			return persons, total, err
+		}
+	}
+
+	var total int64
+	if err := db.Model(&models.Person{}).Scopes(opts.Filter.Scope).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var persons []models.Person
+	err := db.Scopes(opts.Filter.Scope, PreloadEmails).Order(opts.Order).Limit(opts.Limit).Offset(opts.Offset).Find(&persons).Error
+	return persons, total, err
+}
+
+// personWithTotal is a person row along with the COUNT(*) OVER() of all
+// rows matching the query.
+type personWithTotal struct {
+	models.Person
+	Total int64
+}
+
+func listWithWindowTotal(db *gorm.DB, opts ListOptions) ([]models.Person, int64, error) {
+	var rows []personWithTotal
+	err := db.Model(&models.Person{}).Select("people.*, COUNT(*) OVER() AS total").
+		Scopes(opts.Filter.Scope, PreloadEmails).Order(opts.Order).Limit(opts.Limit).Offset(opts.Offset).
+		Find(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return nil, 0, err
+	}
+
+	persons := make([]models.Person, len(rows))
+	for i, row := range rows {
+		persons[i] = row.Person
+	}
+	return persons, rows[0].Total, nil
+}
+
+func (r gormPersonRepository) query(ctx context.Context, opts GetOptions) *gorm.DB {
+	db := Conn(ctx, r.db)
+	if opts.IncludeDeleted {
+		db = db.Unscoped()
+	}
+	if opts.FromPrimary {
+		db = db.Clauses(dbresolver.Write)
+	}
+	if !opts.IncludeInactive {
+		db = db.Where("active")
+	}
+	return db.Scopes(PreloadEmails)
+}
+
+func reloadEmails(tx *gorm.DB, person *models.Person) error {
+	person.Emails = nil
+	return tx.Where("person_id = ? AND NOT is_primary", person.ID).Order("id").Find(&person.Emails).Error
+}
+
+// notFound translates GORM's missing-record error into ErrPersonNotFound.
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrPersonNotFound
+	}
+	return err
+}
+
+// RecordAudit writes an audit entry for a change to one person. It must run
+// in the transaction that makes the change so that a failed audit write
+// rolls the change back.
+func RecordAudit(tx *gorm.DB, action string, before, after *models.Person) error {
+	entry, err := models.NewAuditEntry(action, before, after)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&entry).Error
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"person-service/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestPersonNameFilterMatchesSearchName(t *testing.T) {
+	db := newTenantDryRun(t, context.Background())
+
+	query := db.Scopes(PersonFilter{Name: "JOSÉ_"}.Scope).Find(&[]models.Person{})
+	require.NoError(t, query.Error)
+	assert.Contains(t, query.Statement.SQL.String(), "search_name LIKE $1")
+	assert.Equal(t, `%jose\_%`, query.Statement.Vars[0])
+}
+
+func TestUpdateRefreshesSearchName(t *testing.T) {
+	db := newTenantDryRun(t, context.Background())
+
+	update := db.Model(&models.Person{ID: 1}).Updates(map[string]interface{}{"name": "Zoë"})
+	require.NoError(t, update.Error)
+	assert.Contains(t, update.Statement.SQL.String(), `"search_name"=`)
+	assert.Contains(t, update.Statement.Vars, "zoe")
+
+	update = db.Model(&models.Person{ID: 1}).Updates(map[string]interface{}{"email": "zoe@example.com"})
+	require.NoError(t, update.Error)
+	assert.NotContains(t, update.Statement.SQL.String(), "search_name")
+}
+
+func TestPersonFilterHidesInactiveByDefault(t *testing.T) {
+	db := newTenantDryRun(t, context.Background())
+
+	query := db.Scopes(PersonFilter{}.Scope).Find(&[]models.Person{})
+	require.NoError(t, query.Error)
+	assert.Contains(t, query.Statement.SQL.String(), "WHERE active")
+
+	query = db.Scopes(PersonFilter{IncludeInactive: true}.Scope).Find(&[]models.Person{})
+	require.NoError(t, query.Error)
+	assert.NotContains(t, query.Statement.SQL.String(), "active")
+}
+
+func TestListWindowTotalCountsInPageQuery(t *testing.T) {
+	db := newTenantDryRun(t, context.Background())
+	var statements []string
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}))
+	persons := NewPersonRepository(db, true)
+
+	_, _, err := persons.List(context.Background(), ListOptions{Order: "id asc", Limit: 10, WindowTotal: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`SELECT people.*, COUNT(*) OVER() AS total FROM "people" WHERE active AND "people"."deleted_at" IS NULL ORDER BY id asc LIMIT $1`,
+	}, statements)
+
+	// An empty page past the first cannot tell the total, so it is counted.
+	statements = nil
+	_, _, err = persons.List(context.Background(), ListOptions{Order: "id asc", Limit: 10, Offset: 10, WindowTotal: true})
+
+	require.NoError(t, err)
+	require.Len(t, statements, 3)
+	assert.Contains(t, statements[1], "SELECT count(*)")
+}
+
+func TestTakenEmailsChecksEveryAddressOfTheTenant(t *testing.T) {
+	tenant := uuid.New()
+	db := newTenantDryRun(t, WithTenant(context.Background(), tenant))
+	var statements []string
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}))
+
+	_, err := TakenEmails(db, []string{"jane@example.com"}, 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`SELECT DISTINCT "person_emails"."email" FROM "people" JOIN person_emails ON person_emails.person_id = people.id ` +
+			`WHERE (person_emails.email IN ($1) AND people.id <> $2) AND "people"."tenant_id" = $3 AND "people"."deleted_at" IS NULL`,
+	}, statements)
+}
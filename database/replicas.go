@@ -0,0 +1,37 @@
+package database
+
+import (
+	"person-service/config"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// UseReplicas routes queries (First, Find, Count and raw SELECTs) to the
+// configured read replicas and leaves creates, updates, deletes and
+// transactions on the primary. Without replicas db is left untouched.
+//
+// Replicas may lag behind the primary; reads that must observe the latest
+// write should add the dbresolver.Write clause.
+func UseReplicas(db *gorm.DB, cfg config.DatabaseConfig) error {
+	if len(cfg.ReplicaURLs) == 0 {
+		return nil
+	}
+
+	replicas := make([]gorm.Dialector, 0, len(cfg.ReplicaURLs))
+	for _, url := range cfg.ReplicaURLs {
+		replicas = append(replicas, postgres.Open(url))
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	}).
+		SetMaxOpenConns(cfg.MaxOpenConns).
+		SetMaxIdleConns(cfg.MaxIdleConns).
+		SetConnMaxLifetime(cfg.ConnMaxLifetime).
+		SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	return db.Use(resolver)
+}
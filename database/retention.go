@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"person-service/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// purgeBatch is how many persons PurgeCreatedBefore deletes per statement,
+// keeping each delete's locks short.
+const purgeBatch = 500
+
+// PurgeCreatedBefore permanently deletes every person created before cutoff,
+// across all tenants and soft-deleted ones included, and returns how many it
+// deleted. Their audit entries go with them, so no snapshot of the person is
+// left behind. Each deleted row is announced on the change feed, and the IDs
+// of every deleted batch are passed to onPurge, if set, e.g. to evict them
+// from a cache.
+func PurgeCreatedBefore(ctx context.Context, db *gorm.DB, cutoff time.Time, onPurge func(ids []uint)) (int64, error) {
+	db = db.WithContext(ctx)
+
+	var purged int64
+	for {
+		expired := db.Model(&models.Person{}).Unscoped().Select("id").
+			Where("created_at < ?", cutoff).Limit(purgeBatch)
+		var deleted []models.Person
+		result := db.Unscoped().Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+			Where("id IN (?)", expired).Delete(&deleted)
+		if result.Error != nil {
+			return purged, fmt.Errorf("purge persons: %w", result.Error)
+		}
+		purged += result.RowsAffected
+		if onPurge != nil && len(deleted) > 0 {
+			ids := make([]uint, len(deleted))
+			for i, person := range deleted {
+				ids[i] = person.ID
+			}
+			onPurge(ids)
+		}
+		if result.RowsAffected < purgeBatch {
+			return purged, nil
+		}
+	}
+}
+
+// RunRetention purges persons older than retention every interval, starting
+// with one purge straight away, until ctx is done. onPurge is passed on to
+// PurgeCreatedBefore. A failed run is logged and retried at the next tick.
+func RunRetention(ctx context.Context, db *gorm.DB, retention, interval time.Duration, onPurge func(ids []uint)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := PurgeCreatedBefore(ctx, db, time.Now().Add(-retention), onPurge)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			slog.ErrorContext(ctx, "Retention purge failed", "purged", purged, "error", err)
+		default:
+			slog.InfoContext(ctx, "Retention purge completed", "purged", purged, "retention", retention.String())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
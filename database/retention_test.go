@@ -0,0 +1,26 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestPurgeCreatedBeforeDeletesPermanentlyInBatches(t *testing.T) {
+	db := newTenantDryRun(t, context.Background())
+	var statements []string
+	require.NoError(t, db.Callback().Delete().After("gorm:delete").Register("test:capture", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}))
+
+	_, err := PurgeCreatedBefore(context.Background(), db, time.Now(), nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`DELETE FROM "people" WHERE id IN (SELECT "id" FROM "people" WHERE created_at < $1 LIMIT $2) RETURNING "id"`,
+	}, statements)
+}
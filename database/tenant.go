@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const tenantColumn = "tenant_id"
+
+type tenantKey struct{}
+
+// WithTenant scopes every query run with ctx (via db.WithContext) to tenant.
+func WithTenant(ctx context.Context, tenant uuid.UUID) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+func TenantFromContext(ctx context.Context) (uuid.UUID, bool) {
+	tenant, ok := ctx.Value(tenantKey{}).(uuid.UUID)
+	return tenant, ok
+}
+
+// RegisterTenantScope makes every operation on a model with a tenant_id
+// column tenant-aware when its context carries a tenant: queries, updates
+// and deletes get a tenant_id condition, and creates have the tenant
+// assigned, overriding whatever the caller set. Statements without a tenant
+// in their context (migrations, metrics) are left untouched.
+func RegisterTenantScope(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("tenant:assign", assignTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("tenant:scope", scopeTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("tenant:scope", scopeTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("tenant:scope", scopeTenant); err != nil {
+		return err
+	}
+	return callbacks.Row().Before("gorm:row").Register("tenant:scope", scopeTenant)
+}
+
+func scopeTenant(tx *gorm.DB) {
+	tenant, ok := statementTenant(tx)
+	if !ok {
+		return
+	}
+	tx.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: tenantColumn}, Value: tenant},
+	}})
+}
+
+func assignTenant(tx *gorm.DB) {
+	tenant, ok := statementTenant(tx)
+	if !ok {
+		return
+	}
+	field := tx.Statement.Schema.LookUpField(tenantColumn)
+	ctx := tx.Statement.Context
+
+	switch value := tx.Statement.ReflectValue; value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := field.Set(ctx, reflect.Indirect(value.Index(i)), tenant); err != nil {
+				_ = tx.AddError(err)
+				return
+			}
+		}
+	case reflect.Struct:
+		if err := field.Set(ctx, value, tenant); err != nil {
+			_ = tx.AddError(err)
+		}
+	}
+}
+
+// statementTenant returns the tenant of the statement's context if the
+// statement targets a model with a tenant_id column.
+func statementTenant(tx *gorm.DB) (uuid.UUID, bool) {
+	if tx.Error != nil || tx.Statement.Schema == nil || tx.Statement.Schema.LookUpField(tenantColumn) == nil {
+		return uuid.Nil, false
+	}
+	return TenantFromContext(tx.Statement.Context)
+}
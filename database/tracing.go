@@ -0,0 +1,75 @@
+package database
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	tracerName      = "person-service/database"
+	spanInstanceKey = "otel:span"
+)
+
+// RegisterTracing wraps every GORM operation in a client span that is a
+// child of the span in the statement's context (the request span when
+// queries use db.WithContext).
+func RegisterTracing(db *gorm.DB, provider trace.TracerProvider) error {
+	tracer := provider.Tracer(tracerName)
+
+	before := func(operation string) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			ctx, _ := tracer.Start(tx.Statement.Context, "gorm."+operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBOperationName(operation)),
+			)
+			tx.InstanceSet(spanInstanceKey, trace.SpanFromContext(ctx))
+		}
+	}
+
+	after := func(tx *gorm.DB) {
+		value, ok := tx.InstanceGet(spanInstanceKey)
+		if !ok {
+			return
+		}
+		span := value.(trace.Span)
+		defer span.End()
+
+		if tx.Statement.Table != "" {
+			span.SetAttributes(semconv.DBCollectionName(tx.Statement.Table))
+		}
+		if query := tx.Statement.SQL.String(); query != "" {
+			span.SetAttributes(semconv.DBQueryText(query))
+		}
+		if tx.Error != nil && !errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			span.RecordError(tx.Error)
+			span.SetStatus(codes.Error, tx.Error.Error())
+		}
+	}
+
+	callbacks := db.Callback()
+	registrations := []struct {
+		operation string
+		before    func(string, func(*gorm.DB)) error
+		after     func(string, func(*gorm.DB)) error
+	}{
+		{"create", callbacks.Create().Before("gorm:create").Register, callbacks.Create().After("gorm:create").Register},
+		{"query", callbacks.Query().Before("gorm:query").Register, callbacks.Query().After("gorm:query").Register},
+		{"update", callbacks.Update().Before("gorm:update").Register, callbacks.Update().After("gorm:update").Register},
+		{"delete", callbacks.Delete().Before("gorm:delete").Register, callbacks.Delete().After("gorm:delete").Register},
+		{"row", callbacks.Row().Before("gorm:row").Register, callbacks.Row().After("gorm:row").Register},
+		{"raw", callbacks.Raw().Before("gorm:raw").Register, callbacks.Raw().After("gorm:raw").Register},
+	}
+	for _, r := range registrations {
+		if err := r.before("otel:before_"+r.operation, before(r.operation)); err != nil {
+			return err
+		}
+		if err := r.after("otel:after_"+r.operation, after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
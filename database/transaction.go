@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+const uniqueViolationCode = "23505"
+
+// UniqueViolationError reports that a write hit a unique constraint. The
+// constraint name tells callers which value was taken.
+type UniqueViolationError struct {
+	Constraint string
+	Err        error
+}
+
+func (e *UniqueViolationError) Error() string {
+	return fmt.Sprintf("unique constraint %q violated: %v", e.Constraint, e.Err)
+}
+
+func (e *UniqueViolationError) Unwrap() error {
+	return e.Err
+}
+
+// Transaction runs fn in a database transaction, committing when it returns
+// nil and rolling back otherwise. Postgres unique violations (SQLSTATE 23505)
+// are returned as *UniqueViolationError so callers can answer with a
+// conflict instead of a server error.
+func Transaction(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	return WrapUniqueViolation(db.Transaction(fn))
+}
+
+type txKey struct{}
+
+// requestTx is a transaction attached to a context by WithTx, along with the
+// side effects waiting for it to commit.
+type requestTx struct {
+	tx *gorm.DB
+
+	mu          sync.Mutex
+	afterCommit []func()
+}
+
+// WithTx makes Conn return tx for ctx, so every query run for a request
+// joins the transaction the request was given. Functions passed to
+// AfterCommit with the returned context are held back until the caller
+// reports the commit through committed; after a rollback they are dropped.
+func WithTx(ctx context.Context, tx *gorm.DB) (txCtx context.Context, committed func()) {
+	rtx := &requestTx{tx: tx}
+	committed = func() {
+		rtx.mu.Lock()
+		fns := rtx.afterCommit
+		rtx.afterCommit = nil
+		rtx.mu.Unlock()
+		for _, fn := range fns {
+			fn()
+		}
+	}
+	return context.WithValue(ctx, txKey{}, rtx), committed
+}
+
+// AfterCommit runs fn once the writes made through Conn(ctx, ...) are
+// committed: right away, or when the transaction attached by WithTx
+// commits. Side effects that must not be seen for a rolled-back write, such
+// as events and cache evictions, go through it.
+func AfterCommit(ctx context.Context, fn func()) {
+	rtx, ok := ctx.Value(txKey{}).(*requestTx)
+	if !ok {
+		fn()
+		return
+	}
+	rtx.mu.Lock()
+	defer rtx.mu.Unlock()
+	rtx.afterCommit = append(rtx.afterCommit, fn)
+}
+
+// Conn returns the transaction attached to ctx by WithTx, or db when there
+// is none, bound to ctx. Transactions started on the result become
+// savepoints of the request's transaction.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if rtx, ok := ctx.Value(txKey{}).(*requestTx); ok {
+		return rtx.tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// WrapUniqueViolation returns a Postgres unique violation as
+// *UniqueViolationError and any other error unchanged.
+func WrapUniqueViolation(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		return &UniqueViolationError{Constraint: pgErr.ConstraintName, Err: err}
+	}
+	return err
+}
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAfterCommitRunsRightAwayWithoutTransaction(t *testing.T) {
+	ran := false
+	AfterCommit(context.Background(), func() { ran = true })
+	assert.True(t, ran)
+}
+
+func TestAfterCommitWaitsForCommit(t *testing.T) {
+	ctx, committed := WithTx(context.Background(), nil)
+
+	var ran []int
+	AfterCommit(ctx, func() { ran = append(ran, 1) })
+	AfterCommit(ctx, func() { ran = append(ran, 2) })
+	assert.Empty(t, ran)
+
+	committed()
+	assert.Equal(t, []int{1, 2}, ran)
+
+	committed()
+	assert.Equal(t, []int{1, 2}, ran, "a second commit report must not rerun side effects")
+}
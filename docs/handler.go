@@ -0,0 +1,12 @@
+package docs
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler serves the generated OpenAPI document as raw JSON.
+func Handler(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(SwaggerInfo.ReadDoc()))
+}
@@ -0,0 +1,149 @@
+package emailcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"person-service/models"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	lookupTimeout = 2 * time.Second
+	cacheTTL      = 10 * time.Minute
+	// maxCacheEntries bounds the cache; once full it is cleared rather than
+	// tracking recency, since lookups are cheap to redo.
+	maxCacheEntries = 10000
+)
+
+// Verifier checks that an email address can receive mail.
+type Verifier interface {
+	// Verify returns an error describing why email is undeliverable, or nil.
+	Verify(ctx context.Context, email string) error
+}
+
+// NoopVerifier accepts every address.
+type NoopVerifier struct{}
+
+func (NoopVerifier) Verify(context.Context, string) error {
+	return nil
+}
+
+// Resolver is the subset of net.Resolver used for lookups.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// MXVerifier rejects addresses whose domain has no mail exchanger. Domains
+// without MX records fall back to their address records, as mail servers
+// do, and a null MX ("." per RFC 7505) counts as refusing mail.
+//
+// DNS failures other than a missing domain, including timeouts, let the
+// address through: a flaky resolver should not block saves.
+type MXVerifier struct {
+	resolver Resolver
+	timeout  time.Duration
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	results map[string]mxResult
+}
+
+type mxResult struct {
+	err       error
+	expiresAt time.Time
+}
+
+func NewMXVerifier(resolver Resolver) *MXVerifier {
+	return &MXVerifier{
+		resolver: resolver,
+		timeout:  lookupTimeout,
+		ttl:      cacheTTL,
+		now:      time.Now,
+		results:  make(map[string]mxResult),
+	}
+}
+
+func (v *MXVerifier) Verify(ctx context.Context, email string) error {
+	_, domain, ok := strings.Cut(models.NormalizeEmail(email), "@")
+	if !ok {
+		return nil
+	}
+
+	if err, ok := v.cached(domain); ok {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	err, conclusive := v.lookup(ctx, domain)
+	if !conclusive {
+		slog.WarnContext(ctx, "Email domain lookup failed; accepting address", "domain", domain, "error", err)
+		return nil
+	}
+	v.store(domain, err)
+	return err
+}
+
+// lookup reports whether domain accepts mail. conclusive is false when DNS
+// could not give a definite answer.
+func (v *MXVerifier) lookup(ctx context.Context, domain string) (err error, conclusive bool) {
+	records, err := v.resolver.LookupMX(ctx, domain)
+	if err == nil {
+		if len(records) == 1 && strings.TrimSuffix(records[0].Host, ".") == "" {
+			return fmt.Errorf("email domain %s does not accept mail", domain), true
+		}
+		if len(records) > 0 {
+			return nil, true
+		}
+	} else if !isNotFound(err) {
+		return err, false
+	}
+
+	_, err = v.resolver.LookupHost(ctx, domain)
+	switch {
+	case err == nil:
+		return nil, true
+	case isNotFound(err):
+		return fmt.Errorf("email domain %s cannot receive mail", domain), true
+	default:
+		return err, false
+	}
+}
+
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+func (v *MXVerifier) cached(domain string) (error, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	result, ok := v.results[domain]
+	if !ok {
+		return nil, false
+	}
+	if !v.now().Before(result.expiresAt) {
+		delete(v.results, domain)
+		return nil, false
+	}
+	return result.err, true
+}
+
+func (v *MXVerifier) store(domain string, err error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if len(v.results) >= maxCacheEntries {
+		clear(v.results)
+	}
+	v.results[domain] = mxResult{err: err, expiresAt: v.now().Add(v.ttl)}
+}
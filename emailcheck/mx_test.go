@@ -0,0 +1,88 @@
+package emailcheck
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubResolver struct {
+	mx      map[string][]*net.MX
+	hosts   map[string][]string
+	err     error
+	lookups int
+}
+
+func (r *stubResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	r.lookups++
+	if r.err != nil {
+		return nil, r.err
+	}
+	if records, ok := r.mx[name]; ok {
+		return records, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *stubResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func TestMXVerifierAcceptsDomainWithMX(t *testing.T) {
+	resolver := &stubResolver{mx: map[string][]*net.MX{"example.com": {{Host: "mail.example.com.", Pref: 10}}}}
+	verifier := NewMXVerifier(resolver)
+
+	assert.NoError(t, verifier.Verify(context.Background(), "Jane@Example.com"))
+}
+
+func TestMXVerifierRejectsUnresolvableDomain(t *testing.T) {
+	verifier := NewMXVerifier(&stubResolver{})
+
+	err := verifier.Verify(context.Background(), "jane@no-such-domain.invalid")
+
+	assert.EqualError(t, err, "email domain no-such-domain.invalid cannot receive mail")
+}
+
+func TestMXVerifierFallsBackToAddressRecords(t *testing.T) {
+	resolver := &stubResolver{hosts: map[string][]string{"example.com": {"192.0.2.1"}}}
+	verifier := NewMXVerifier(resolver)
+
+	assert.NoError(t, verifier.Verify(context.Background(), "jane@example.com"))
+}
+
+func TestMXVerifierRejectsNullMX(t *testing.T) {
+	resolver := &stubResolver{mx: map[string][]*net.MX{"example.com": {{Host: ".", Pref: 0}}}}
+	verifier := NewMXVerifier(resolver)
+
+	assert.EqualError(t, verifier.Verify(context.Background(), "jane@example.com"), "email domain example.com does not accept mail")
+}
+
+func TestMXVerifierAcceptsOnLookupFailure(t *testing.T) {
+	resolver := &stubResolver{err: &net.DNSError{Err: "i/o timeout", IsTimeout: true}}
+	verifier := NewMXVerifier(resolver)
+
+	assert.NoError(t, verifier.Verify(context.Background(), "jane@example.com"))
+	assert.NoError(t, verifier.Verify(context.Background(), "jane@example.com"))
+	assert.Equal(t, 2, resolver.lookups, "inconclusive lookups are not cached")
+}
+
+func TestMXVerifierCachesResults(t *testing.T) {
+	resolver := &stubResolver{}
+	verifier := NewMXVerifier(resolver)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	verifier.now = func() time.Time { return now }
+
+	assert.Error(t, verifier.Verify(context.Background(), "jane@example.com"))
+	assert.Error(t, verifier.Verify(context.Background(), "john@example.com"))
+	assert.Equal(t, 1, resolver.lookups)
+
+	now = now.Add(cacheTTL)
+	assert.Error(t, verifier.Verify(context.Background(), "jane@example.com"))
+	assert.Equal(t, 2, resolver.lookups)
+}
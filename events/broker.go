@@ -0,0 +1,71 @@
+package events
+
+import "sync"
+
+// Broker fans events out to every current subscriber. Publish never
+// blocks: a subscriber that falls a full buffer behind is dropped and its
+// channel closed, so one slow client cannot hold up the others.
+type Broker[T any] struct {
+	mu          sync.Mutex
+	buffer      int
+	subscribers map[chan T]struct{}
+	closed      bool
+}
+
+func NewBroker[T any](buffer int) *Broker[T] {
+	return &Broker[T]{buffer: buffer, subscribers: make(map[chan T]struct{})}
+}
+
+// Subscribe returns a channel receiving every event published from now on
+// and a function that ends the subscription. The channel is closed when
+// the subscription ends, the subscriber is dropped or the broker closes.
+func (b *Broker[T]) Subscribe() (<-chan T, func()) {
+	ch := make(chan T, b.buffer)
+
+	b.mu.Lock()
+	if b.closed {
+		close(ch)
+	} else {
+		b.subscribers[ch] = struct{}{}
+	}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(ch)
+	}
+}
+
+func (b *Broker[T]) Publish(event T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			b.remove(ch)
+		}
+	}
+}
+
+// Close ends every subscription, e.g. so streaming responses finish when
+// the server shuts down. Later subscriptions are closed straight away.
+func (b *Broker[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for ch := range b.subscribers {
+		b.remove(ch)
+	}
+}
+
+// remove must be called with b.mu held.
+func (b *Broker[T]) remove(ch chan T) {
+	if _, ok := b.subscribers[ch]; ok {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
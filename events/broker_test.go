@@ -0,0 +1,51 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBrokerFansOut(t *testing.T) {
+	broker := NewBroker[int](1)
+	first, unsubscribeFirst := broker.Subscribe()
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := broker.Subscribe()
+	defer unsubscribeSecond()
+
+	broker.Publish(1)
+
+	assert.Equal(t, 1, <-first)
+	assert.Equal(t, 1, <-second)
+}
+
+func TestBrokerDropsSlowSubscriber(t *testing.T) {
+	broker := NewBroker[int](1)
+	slow, unsubscribeSlow := broker.Subscribe()
+	defer unsubscribeSlow()
+	fast, unsubscribeFast := broker.Subscribe()
+	defer unsubscribeFast()
+
+	broker.Publish(1)
+	<-fast
+	broker.Publish(2)
+
+	assert.Equal(t, 2, <-fast)
+	assert.Equal(t, 1, <-slow)
+	_, open := <-slow
+	assert.False(t, open, "slow subscriber should have been dropped")
+}
+
+func TestBrokerCloseEndsSubscriptions(t *testing.T) {
+	broker := NewBroker[int](1)
+	ch, unsubscribe := broker.Subscribe()
+
+	broker.Close()
+	unsubscribe()
+
+	_, open := <-ch
+	assert.False(t, open)
+	late, _ := broker.Subscribe()
+	_, open = <-late
+	assert.False(t, open)
+}
@@ -0,0 +1,23 @@
+package events
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// PersonChange is a person change announced by the database on
+// database.PersonChangesChannel. Event is PersonCreated, PersonUpdated or
+// PersonDeleted.
+type PersonChange struct {
+	Event      string    `json:"event"`
+	ID         uint      `json:"id"`
+	ExternalID uuid.UUID `json:"external_id"`
+	TenantID   uuid.UUID `json:"tenant_id"`
+}
+
+func ParsePersonChange(payload string) (PersonChange, error) {
+	var change PersonChange
+	err := json.Unmarshal([]byte(payload), &change)
+	return change, err
+}
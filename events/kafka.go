@@ -0,0 +1,69 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"person-service/models"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	kafkaPublishTimeout = 3 * time.Second
+	// kafkaBatchTimeout bounds how long a message waits for others to fill
+	// its batch. Each request publishes its own events, so waiting for kafka-go's
+	// default of a second would only add that second to the request.
+	kafkaBatchTimeout = 5 * time.Millisecond
+)
+
+// KafkaPublisher produces person events as JSON to a Kafka topic, keyed by
+// external_id so all events for a person land on the same partition.
+type KafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaPublisher(brokers []string, topic string) *KafkaPublisher {
+	return &KafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Topic:                  topic,
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireOne,
+			MaxAttempts:            3,
+			BatchTimeout:           kafkaBatchTimeout,
+			WriteTimeout:           kafkaPublishTimeout,
+			AllowAutoTopicCreation: true,
+		},
+	}
+}
+
+// PublishCreated writes the persons' events in one call, so kafka-go batches
+// them instead of waiting on the broker once per person. It gives up after
+// kafkaPublishTimeout so an unavailable broker delays, but never blocks, the
+// create request.
+func (p *KafkaPublisher) PublishCreated(ctx context.Context, persons ...models.Person) error {
+	if len(persons) == 0 {
+		return nil
+	}
+	messages := make([]kafka.Message, len(persons))
+	for i, person := range persons {
+		payload, err := json.Marshal(person)
+		if err != nil {
+			return err
+		}
+		messages[i] = kafka.Message{
+			Key:   []byte(person.ExternalID.String()),
+			Value: payload,
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, kafkaPublishTimeout)
+	defer cancel()
+
+	return p.writer.WriteMessages(ctx, messages...)
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
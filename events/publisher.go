@@ -0,0 +1,57 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"person-service/models"
+)
+
+// PersonEventPublisher notifies downstream systems about person changes.
+// PublishCreated takes every person a request created, so publishers can
+// send them in one batch.
+type PersonEventPublisher interface {
+	PublishCreated(ctx context.Context, persons ...models.Person) error
+}
+
+// NoopPublisher discards all events.
+type NoopPublisher struct{}
+
+func (NoopPublisher) PublishCreated(context.Context, ...models.Person) error {
+	return nil
+}
+
+// LogPublisher writes events to the structured log.
+type LogPublisher struct{}
+
+func (LogPublisher) PublishCreated(ctx context.Context, persons ...models.Person) error {
+	for _, person := range persons {
+		slog.InfoContext(ctx, "Person created event", "event", "person.created", "person_id", person.ID, "external_id", person.ExternalID)
+	}
+	return nil
+}
+
+// MultiPublisher publishes every event to each of its publishers in turn,
+// returning their joined errors.
+type MultiPublisher []PersonEventPublisher
+
+func (m MultiPublisher) PublishCreated(ctx context.Context, persons ...models.Person) error {
+	var errs []error
+	for _, publisher := range m {
+		errs = append(errs, publisher.PublishCreated(ctx, persons...))
+	}
+	return errors.Join(errs...)
+}
+
+// BrokerPublisher publishes created persons to a Broker, whose subscribers
+// are the clients of the in-process event stream.
+type BrokerPublisher struct {
+	Broker *Broker[models.Person]
+}
+
+func (p BrokerPublisher) PublishCreated(_ context.Context, persons ...models.Person) error {
+	for _, person := range persons {
+		p.Broker.Publish(person)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"person-service/models"
+	"sync"
+	"time"
+)
+
+// Webhook event names.
+const (
+	PersonCreated = "person.created"
+	PersonUpdated = "person.updated"
+	PersonDeleted = "person.deleted"
+)
+
+// SignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of the
+// request body, keyed with the webhook secret.
+const SignatureHeader = "X-Signature"
+
+const (
+	webhookTimeout      = 5 * time.Second
+	webhookAttempts     = 5
+	webhookInitialDelay = time.Second
+	webhookMaxDelay     = 30 * time.Second
+)
+
+// PersonNotifier pushes person changes to subscribers without blocking the
+// caller.
+type PersonNotifier interface {
+	Notify(ctx context.Context, event string, person models.Person)
+}
+
+// NoopNotifier discards all notifications.
+type NoopNotifier struct{}
+
+func (NoopNotifier) Notify(context.Context, string, models.Person) {}
+
+// WebhookPayload is the JSON body posted to webhook subscribers.
+type WebhookPayload struct {
+	Event  string        `json:"event"`
+	Person models.Person `json:"person"`
+}
+
+// WebhookDispatcher posts signed person events to a fixed set of URLs. Each
+// delivery runs in its own goroutine and is retried with exponential
+// backoff until a subscriber answers 2xx or the attempts run out.
+type WebhookDispatcher struct {
+	urls   []string
+	secret []byte
+	client *http.Client
+
+	attempts     int
+	initialDelay time.Duration
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewWebhookDispatcher(urls []string, secret string) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		urls:         urls,
+		secret:       []byte(secret),
+		client:       &http.Client{Timeout: webhookTimeout},
+		attempts:     webhookAttempts,
+		initialDelay: webhookInitialDelay,
+		done:         make(chan struct{}),
+	}
+}
+
+// Notify queues event for every subscriber and returns immediately. ctx is
+// only used for logging; deliveries outlive the request.
+func (d *WebhookDispatcher) Notify(ctx context.Context, event string, person models.Person) {
+	body, err := json.Marshal(WebhookPayload{Event: event, Person: person})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to encode webhook payload", "event", event, "person_id", person.ID, "error", err)
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	signature := Sign(d.secret, body)
+	for _, url := range d.urls {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.deliver(ctx, url, body, signature)
+		}()
+	}
+}
+
+// Close stops retrying failed deliveries and waits for in-flight requests
+// to finish.
+func (d *WebhookDispatcher) Close() {
+	close(d.done)
+	d.wg.Wait()
+}
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, url string, body []byte, signature string) {
+	delay := d.initialDelay
+	for attempt := 1; ; attempt++ {
+		err := d.post(url, body, signature)
+		if err == nil {
+			return
+		}
+		if attempt == d.attempts {
+			slog.ErrorContext(ctx, "Webhook delivery failed", "url", url, "attempts", attempt, "error", err)
+			return
+		}
+
+		slog.WarnContext(ctx, "Webhook delivery attempt failed", "url", url, "attempt", attempt, "retry_in", delay.String(), "error", err)
+		select {
+		case <-time.After(delay):
+		case <-d.done:
+			slog.ErrorContext(ctx, "Webhook delivery abandoned on shutdown", "url", url, "attempts", attempt)
+			return
+		}
+
+		delay *= 2
+		if delay > webhookMaxDelay {
+			delay = webhookMaxDelay
+		}
+	}
+}
+
+func (d *WebhookDispatcher) post(url string, body []byte, signature string) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, signature)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the X-Signature value for body.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
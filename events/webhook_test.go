@@ -0,0 +1,82 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookDispatcherSignsPayload(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+	}))
+	defer server.Close()
+
+	dispatcher := NewWebhookDispatcher([]string{server.URL}, "secret")
+	person := models.Person{ID: 7, ExternalID: uuid.New(), Name: "Jane Doe"}
+	dispatcher.Notify(context.Background(), PersonCreated, person)
+	dispatcher.Close()
+
+	req := <-received
+	body := <-bodies
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	assert.Equal(t, Sign([]byte("secret"), body), req.Header.Get(SignatureHeader))
+
+	var payload WebhookPayload
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, PersonCreated, payload.Event)
+	assert.Equal(t, person.ExternalID, payload.Person.ExternalID)
+	assert.Equal(t, "Jane Doe", payload.Person.Name)
+}
+
+func TestWebhookDispatcherRetriesUntilSuccess(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	dispatcher := NewWebhookDispatcher([]string{server.URL}, "secret")
+	dispatcher.initialDelay = time.Millisecond
+	dispatcher.Notify(context.Background(), PersonUpdated, models.Person{ID: 1})
+	dispatcher.wg.Wait()
+
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestWebhookDispatcherGivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	dispatcher := NewWebhookDispatcher([]string{server.URL}, "secret")
+	dispatcher.initialDelay = time.Millisecond
+	dispatcher.Notify(context.Background(), PersonDeleted, models.Person{ID: 1})
+	dispatcher.wg.Wait()
+
+	assert.Equal(t, int32(webhookAttempts), calls.Load())
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '{}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=77325902caca812dc259733aacd046b73817372c777b8d95b402647474516e13", Sign([]byte("secret"), []byte("{}")))
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"person-service/database"
+	"person-service/events"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnonymizePerson answers an erasure request that must not delete the
+// record: the person's name, email, phone, address and date of birth are
+// replaced with placeholders while the row, its ID and external_id stay, so
+// references from other systems keep resolving. The snapshots in the
+// person's history are cleared too. Anonymizing an anonymized person changes
+// nothing.
+//
+// @Summary      Anonymize person
+// @Tags         persons
+// @Produce      json
+// @Param        id   path      int  true  "Person ID"
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200  {object}  models.PersonResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Failure      503  {object}  models.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /v1/persons/{id}/anonymize [post]
+func (h *PersonHandler) AnonymizePerson(c *gin.Context) {
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid ID format"))
+		return
+	}
+
+	person, err := h.persons.GetByID(ctx, uint(id), database.GetOptions{FromPrimary: true, IncludeInactive: true})
+	if err != nil {
+		if errors.Is(err, database.ErrPersonNotFound) {
+			c.JSON(http.StatusNotFound, errorResponse(c, "Person not found"))
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "Database error retrieving person", "person_id", id, "error", err)
+		respondDBError(c, err, "Failed to retrieve person")
+		return
+	}
+
+	if person.AnonymizedAt != nil {
+		c.JSON(http.StatusOK, person.ToResponse())
+		return
+	}
+
+	err = h.persons.Anonymize(ctx, &person)
+	switch {
+	case errors.Is(err, database.ErrVersionConflict):
+		c.JSON(http.StatusConflict, errorResponse(c, "Person was modified concurrently"))
+		return
+	case err != nil:
+		slog.ErrorContext(c.Request.Context(), "Failed to anonymize person", "person_id", id, "error", err)
+		respondDBError(c, err, "Failed to anonymize person")
+		return
+	}
+
+	database.AfterCommit(c.Request.Context(), func() {
+		h.cache.Delete(person.ID)
+		h.notifier.Notify(c.Request.Context(), events.PersonUpdated, person)
+	})
+	slog.InfoContext(c.Request.Context(), "Anonymized person", "person_id", person.ID)
+	c.JSON(http.StatusOK, person.ToResponse())
+}
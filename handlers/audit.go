@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"person-service/models"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// recordCreateAudits writes a create entry for each of persons.
+func recordCreateAudits(tx *gorm.DB, persons []models.Person) error {
+	entries := make([]models.AuditEntry, 0, len(persons))
+	for i := range persons {
+		entry, err := models.NewAuditEntry(models.AuditCreate, nil, &persons[i])
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+	return tx.CreateInBatches(&entries, insertBatchChunk).Error
+}
+
+// GetPersonHistory lists the audit entries of a person, oldest first. The
+// history of a deleted person remains available.
+//
+// @Summary      Get person change history
+// @Tags         persons
+// @Produce      json
+// @Param        id   path      int  true  "Person ID"
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200  {object}  models.HistoryResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Failure      503  {object}  models.ErrorResponse
+// @Router       /v1/persons/{id}/history [get]
+func (h *PersonHandler) GetPersonHistory(c *gin.Context) {
+	db, cancel := h.queryDB(c)
+	defer cancel()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid ID format"))
+		return
+	}
+
+	var person models.Person
+	if err := db.Unscoped().Select("id").First(&person, uint(id)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, errorResponse(c, "Person not found"))
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "Database error retrieving person", "person_id", id, "error", err)
+		respondDBError(c, err, "Failed to retrieve person history")
+		return
+	}
+
+	entries := []models.AuditEntry{}
+	if err := db.Where("person_id = ?", person.ID).Order("id").Find(&entries).Error; err != nil {
+		slog.ErrorContext(c.Request.Context(), "Database error retrieving person history", "person_id", id, "error", err)
+		respondDBError(c, err, "Failed to retrieve person history")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.HistoryResponse{Data: entries})
+}
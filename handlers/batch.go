@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"person-service/database"
+	"person-service/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxBatchSize     = 100
+	insertBatchChunk = 50
+)
+
+// SavePersonsBatch creates several persons in one request. Items that fail
+// validation or collide with an existing person are reported individually
+// and skipped (best-effort); the remaining items are inserted in a single
+// transaction, so a database failure rolls back the whole batch
+// (all-or-nothing). The response is always 207 Multi-Status with one result
+// per submitted item, in request order.
+//
+// @Summary      Create persons in bulk
+// @Tags         persons
+// @Accept       json
+// @Produce      json
+// @Param        persons  body      []models.SavePersonRequest  true  "Up to 100 persons"
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      207      {object}  models.BatchSaveResponse
+// @Failure      400      {object}  models.ErrorResponse
+// @Failure      401      {object}  models.ErrorResponse
+// @Failure      409      {object}  models.ErrorResponse
+// @Failure      413      {object}  models.ErrorResponse
+// @Failure      415      {object}  models.ErrorResponse
+// @Failure      500      {object}  models.ErrorResponse
+// @Failure      503      {object}  models.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /v1/save/batch [post]
+func (h *PersonHandler) SavePersonsBatch(c *gin.Context) {
+	db, cancel := h.queryDB(c)
+	defer cancel()
+
+	var reqs []models.SavePersonRequest
+
+	if err := json.NewDecoder(c.Request.Body).Decode(&reqs); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if len(reqs) == 0 {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request: batch must contain at least one person"))
+		return
+	}
+	if len(reqs) > maxBatchSize {
+		c.JSON(http.StatusBadRequest, errorResponse(c, fmt.Sprintf("Invalid request: batch cannot exceed %d persons", maxBatchSize)))
+		return
+	}
+
+	results := make([]models.BatchItemResult, len(reqs))
+	candidates := make([]models.Person, len(reqs))
+	seenExternalIDs := make(map[uuid.UUID]bool, len(reqs))
+	seenEmails := make(map[string]bool, len(reqs))
+	var pending []int
+
+	for i := range reqs {
+		results[i].Index = i
+
+		if err := binding.Validator.ValidateStruct(&reqs[i]); err != nil {
+			resp := bindingErrorResponse(c, err)
+			results[i].Status = bindErrorStatus(err)
+			results[i].Error = resp.Error
+			results[i].Details = resp.Details
+			continue
+		}
+		if err := reqs[i].Validate(); err != nil {
+			results[i].Status = http.StatusUnprocessableEntity
+			results[i].Error = "Validation error: " + err.Error()
+			continue
+		}
+		if err := h.emails.Verify(c.Request.Context(), reqs[i].PrimaryEmail()); err != nil {
+			results[i].Status = http.StatusUnprocessableEntity
+			results[i].Error = "Validation error: " + err.Error()
+			continue
+		}
+
+		candidates[i] = models.FromSaveRequest(reqs[i])
+		addresses := candidates[i].EmailAddresses()
+		if seenExternalIDs[reqs[i].ExternalID] {
+			results[i].Status = http.StatusConflict
+			results[i].Error = "Duplicate external_id within batch"
+			continue
+		}
+		if h.uniqueEmail && containsAny(seenEmails, addresses) {
+			results[i].Status = http.StatusConflict
+			results[i].Error = "Duplicate email within batch"
+			continue
+		}
+		seenExternalIDs[reqs[i].ExternalID] = true
+		for _, address := range addresses {
+			seenEmails[address] = true
+		}
+		pending = append(pending, i)
+	}
+
+	var createdPersons []models.Person
+	err := database.Transaction(db, func(tx *gorm.DB) error {
+		if len(pending) == 0 {
+			return nil
+		}
+
+		externalIDs := make([]uuid.UUID, 0, len(pending))
+		emails := make([]string, 0, len(pending))
+		for _, i := range pending {
+			externalIDs = append(externalIDs, reqs[i].ExternalID)
+			emails = append(emails, candidates[i].EmailAddresses()...)
+		}
+
+		var existing []models.Person
+		if err := tx.Select("external_id").Where("external_id IN ?", externalIDs).Find(&existing).Error; err != nil {
+			return err
+		}
+		existingExternalIDs := make(map[uuid.UUID]bool, len(existing))
+		for _, person := range existing {
+			existingExternalIDs[person.ExternalID] = true
+		}
+		existingEmails := make(map[string]bool)
+		if h.uniqueEmail {
+			taken, err := database.TakenEmails(tx, emails, 0)
+			if err != nil {
+				return err
+			}
+			for _, email := range taken {
+				existingEmails[email] = true
+			}
+		}
+
+		var persons []models.Person
+		var created []int
+		for _, i := range pending {
+			switch {
+			case existingExternalIDs[reqs[i].ExternalID]:
+				results[i].Status = http.StatusConflict
+				results[i].Error = "Person with this external_id already exists"
+			case containsAny(existingEmails, candidates[i].EmailAddresses()):
+				results[i].Status = http.StatusConflict
+				results[i].Error = "Person with this email already exists"
+			default:
+				persons = append(persons, candidates[i])
+				created = append(created, i)
+			}
+		}
+
+		if len(persons) == 0 {
+			return nil
+		}
+		if err := tx.CreateInBatches(&persons, insertBatchChunk).Error; err != nil {
+			return err
+		}
+		if err := recordCreateAudits(tx, persons); err != nil {
+			return err
+		}
+
+		for n, i := range created {
+			resp := persons[n].ToResponse()
+			results[i].Status = http.StatusCreated
+			results[i].Person = &resp
+		}
+		createdPersons = persons
+		return nil
+	})
+	var violation *database.UniqueViolationError
+	if errors.As(err, &violation) {
+		// Another request inserted one of the persons after the lookup above.
+		c.JSON(http.StatusConflict, errorResponse(c, "A person in the batch was created concurrently; retry the batch"))
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to save person batch", "batch_size", len(reqs), "error", err)
+		respondDBError(c, err, "Failed to save persons")
+		return
+	}
+
+	h.publishCreated(c.Request.Context(), createdPersons...)
+
+	resp := models.BatchSaveResponse{Results: results}
+	for _, result := range results {
+		if result.Status == http.StatusCreated {
+			resp.Created++
+		} else {
+			resp.Failed++
+		}
+	}
+
+	slog.InfoContext(c.Request.Context(), "Saved person batch", "created", resp.Created, "failed", resp.Failed)
+	c.JSON(http.StatusMultiStatus, resp)
+}
+
+// containsAny reports whether set holds any of values.
+func containsAny(set map[string]bool, values []string) bool {
+	for _, v := range values {
+		if set[v] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"person-service/database"
+	"person-service/events"
+	"person-service/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeletePersons soft-deletes every person matching the filters in one
+// transaction. It refuses to run without ?confirm=true or without any
+// filter, so a stray request cannot wipe a tenant.
+//
+// @Summary      Delete persons matching filters
+// @Tags         persons
+// @Produce      json
+// @Param        confirm      query     bool    true   "Must be true"
+// @Param        name         query     string  false  "Case- and accent-insensitive name substring"
+// @Param        email        query     string  false  "Exact email"
+// @Param        email_domain query     string  false  "Exact email domain, e.g. example.com"
+// @Param        born_after   query     string  false  "RFC3339 timestamp or YYYY-MM-DD"
+// @Param        born_before  query     string  false  "RFC3339 timestamp or YYYY-MM-DD"
+// @Param        include_inactive  query  bool  false  "Include deactivated persons"
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200          {object}  models.BulkDeleteResponse
+// @Failure      400          {object}  models.ErrorResponse
+// @Failure      401          {object}  models.ErrorResponse
+// @Failure      500          {object}  models.ErrorResponse
+// @Failure      503          {object}  models.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /v1/persons [delete]
+func (h *PersonHandler) DeletePersons(c *gin.Context) {
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
+	if c.Query("confirm") != "true" {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Bulk delete requires confirm=true"))
+		return
+	}
+
+	filter, err := parsePersonFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid query: "+err.Error()))
+		return
+	}
+	// include_inactive widens a filter rather than narrowing it, so it
+	// does not count as a criterion on its own.
+	criteria := filter
+	criteria.IncludeInactive = false
+	if criteria == (database.PersonFilter{}) {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Bulk delete requires at least one filter"))
+		return
+	}
+
+	persons, err := h.persons.DeleteMatching(ctx, filter)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to bulk delete persons", "error", err)
+		respondDBError(c, err, "Failed to delete persons")
+		return
+	}
+
+	database.AfterCommit(c.Request.Context(), func() {
+		for _, person := range persons {
+			h.cache.Delete(person.ID)
+			h.notifier.Notify(c.Request.Context(), events.PersonDeleted, person)
+		}
+	})
+	slog.InfoContext(c.Request.Context(), "Bulk deleted persons", "count", len(persons))
+	c.JSON(http.StatusOK, models.BulkDeleteResponse{Deleted: len(persons)})
+}
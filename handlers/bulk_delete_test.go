@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"person-service/database"
+	"person-service/database/databasetest"
+	"person-service/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeletePersonsRequiresConfirmation(t *testing.T) {
+	router := newTestRouter(&databasetest.PersonRepository{})
+
+	code, response := serve(t, router, http.MethodDelete, "/persons?email_domain=example.com", nil)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "Bulk delete requires confirm=true", response.Error)
+}
+
+func TestDeletePersonsRequiresFilter(t *testing.T) {
+	router := newTestRouter(&databasetest.PersonRepository{})
+
+	code, response := serve(t, router, http.MethodDelete, "/persons?confirm=true", nil)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "Bulk delete requires at least one filter", response.Error)
+}
+
+func TestDeletePersonsPassesFilter(t *testing.T) {
+	var got database.PersonFilter
+	router := newTestRouter(&databasetest.PersonRepository{
+		DeleteMatchingFunc: func(ctx context.Context, filter database.PersonFilter) ([]models.Person, error) {
+			got = filter
+			return []models.Person{{ID: 1}, {ID: 2}}, nil
+		},
+	})
+
+	code, _ := serve(t, router, http.MethodDelete, "/persons?confirm=true&email_domain=@Example.COM", nil)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, database.PersonFilter{EmailDomain: "example.com"}, got)
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"net/http"
+	"person-service/database"
+	"person-service/models"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var errInvalidCursor = errors.New("cursor is invalid")
+
+// encodeCursor makes the opaque ?cursor= value for resuming after id.
+func encodeCursor(id uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(uint64(id), 10)))
+}
+
+func decodeCursor(cursor string) (uint, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, errInvalidCursor
+	}
+	id, err := strconv.ParseUint(string(raw), 10, 32)
+	if err != nil {
+		return 0, errInvalidCursor
+	}
+	return uint(id), nil
+}
+
+// listPersonsByCursor serves ListPersons in keyset mode: persons are ordered
+// by id and each page starts after the last id of the previous one, so
+// concurrent inserts never shift rows between pages.
+func (h *PersonHandler) listPersonsByCursor(c *gin.Context, db *gorm.DB, filter database.PersonFilter, fields map[string]bool) {
+	if sort := c.Query("sort"); sort != "" && sort != "id" {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid query: sort is not supported with cursor pagination"))
+		return
+	}
+
+	limit := h.parsePageSize(c, "limit")
+
+	query := db.Scopes(filter.Scope)
+	if cursor := c.Query("cursor"); cursor != "" {
+		afterID, err := decodeCursor(cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid query: "+err.Error()))
+			return
+		}
+		query = query.Where("id > ?", afterID)
+	}
+
+	// Fetch one extra row to learn whether another page follows.
+	var persons []models.Person
+	if err := query.Scopes(database.PreloadEmails).Order("id asc").Limit(limit + 1).Find(&persons).Error; err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to list persons", "error", err)
+		respondDBError(c, err, "Failed to list persons")
+		return
+	}
+
+	resp := models.PersonCursorResponse{Limit: limit}
+	if len(persons) > limit {
+		persons = persons[:limit]
+		resp.NextCursor = encodeCursor(persons[limit-1].ID)
+	}
+	resp.Data = make([]models.PersonResponse, 0, len(persons))
+	for _, person := range persons {
+		resp.Data = append(resp.Data, person.ToResponse())
+	}
+
+	respondPersonFields(c, http.StatusOK, resp, fields)
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"person-service/database"
+	"person-service/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// dryRunPerson reports whether person could be created without creating it.
+// Unlike a real save it cannot lean on the unique indexes, so it looks up
+// live persons sharing the external_id or, with unique emails, any of the
+// person's addresses and reports the same 409s.
+func (h *PersonHandler) dryRunPerson(c *gin.Context, db *gorm.DB, person models.Person) {
+	db = db.Clauses(dbresolver.Write).Session(&gorm.Session{})
+
+	var existing int64
+	err := db.Model(&models.Person{}).Where("external_id = ?", person.ExternalID).Count(&existing).Error
+	if err == nil && existing == 0 && h.uniqueEmail {
+		err = database.CheckEmailsFree(db, person.EmailAddresses(), 0)
+	}
+	switch {
+	case existing > 0:
+		c.JSON(http.StatusConflict, errorResponse(c, "Person with this external_id already exists"))
+		return
+	case isUniqueViolation(err, models.EmailIndex):
+		c.JSON(http.StatusConflict, errorResponse(c, "Person with this email already exists"))
+		return
+	case err != nil:
+		slog.ErrorContext(c.Request.Context(), "Database error checking duplicates", "external_id", person.ExternalID, "error", err)
+		respondDBError(c, err, "Failed to validate person")
+		return
+	}
+
+	c.JSON(http.StatusOK, person.ToResponse())
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"log/slog"
+	"net/http"
+	"person-service/database"
+	"person-service/models"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const exportFlushEvery = 500
+
+var exportHeader = []string{"external_id", "name", "email", "date_of_birth", "created_at"}
+
+// ExportPersonsCSV streams every person as CSV straight from a database
+// cursor. It is bound to the request context rather than the per-query
+// timeout because large exports legitimately take longer.
+//
+// @Summary      Export persons as CSV
+// @Tags         persons
+// @Produce      text/csv
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200  {file}  file
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Router       /v1/persons/export.csv [get]
+func (h *PersonHandler) ExportPersonsCSV(c *gin.Context) {
+	db := database.Conn(c.Request.Context(), h.db)
+
+	rows, err := db.Model(&models.Person{}).Order("id asc").Rows()
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to export persons", "error", err)
+		respondDBError(c, err, "Failed to export persons")
+		return
+	}
+	defer rows.Close()
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="persons.csv"`)
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	if err := writer.Write(exportHeader); err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to write CSV export", "error", err)
+		return
+	}
+
+	exported := 0
+	for rows.Next() {
+		var person models.Person
+		if err := db.ScanRows(rows, &person); err != nil {
+			slog.ErrorContext(c.Request.Context(), "Failed to scan person for export", "error", err)
+			return
+		}
+
+		record := []string{
+			person.ExternalID.String(),
+			person.Name,
+			person.Email,
+			person.DateOfBirth.String(),
+			person.CreatedAt.UTC().Format(time.RFC3339),
+		}
+		if err := writer.Write(record); err != nil {
+			slog.ErrorContext(c.Request.Context(), "Failed to write CSV export", "error", err)
+			return
+		}
+
+		exported++
+		if exported%exportFlushEvery == 0 {
+			writer.Flush()
+			c.Writer.Flush()
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to read persons for export", "error", err)
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to write CSV export", "error", err)
+		return
+	}
+
+	slog.InfoContext(c.Request.Context(), "Exported persons", "count", exported)
+}
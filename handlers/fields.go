@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"person-service/models"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// personFieldNames holds the JSON names of the PersonResponse fields that
+// ?fields= may select.
+var personFieldNames = func() map[string]bool {
+	names := make(map[string]bool)
+	t := reflect.TypeOf(models.PersonResponse{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}()
+
+// parseFields reads the comma-separated ?fields= list. It returns nil when
+// the parameter is absent, meaning every field; otherwise external_id is
+// always part of the selection.
+func parseFields(c *gin.Context) (map[string]bool, error) {
+	value, ok := c.GetQuery("fields")
+	if !ok {
+		return nil, nil
+	}
+
+	fields := map[string]bool{"external_id": true}
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !personFieldNames[name] {
+			return nil, fmt.Errorf("unknown field %q", name)
+		}
+		fields[name] = true
+	}
+	return fields, nil
+}
+
+// respondPersonFields writes data like respondNegotiated, but a JSON response
+// keeps only the selected fields of each person. data is either a
+// PersonResponse or a list response holding its persons under "data". XML
+// responses, and a nil selection, carry every field.
+func respondPersonFields(c *gin.Context, code int, data any, fields map[string]bool) {
+	if fields == nil || c.NegotiateFormat(gin.MIMEJSON, gin.MIMEXML, gin.MIMEXML2) != gin.MIMEJSON {
+		respondNegotiated(c, code, data)
+		return
+	}
+
+	body, err := selectPersonFields(data, fields)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to select person fields", "error", err)
+		c.JSON(http.StatusInternalServerError, errorResponse(c, "Failed to render response"))
+		return
+	}
+	c.JSON(code, body)
+}
+
+func selectPersonFields(data any, fields map[string]bool) (map[string]json.RawMessage, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return nil, err
+	}
+
+	if _, ok := data.(models.PersonResponse); ok {
+		keepFields(body, fields)
+		return body, nil
+	}
+
+	var persons []map[string]json.RawMessage
+	if err := json.Unmarshal(body["data"], &persons); err != nil {
+		return nil, err
+	}
+	for _, person := range persons {
+		keepFields(person, fields)
+	}
+	body["data"], err = json.Marshal(persons)
+	return body, err
+}
+
+func keepFields(person map[string]json.RawMessage, fields map[string]bool) {
+	for name := range person {
+		if !fields[name] {
+			delete(person, name)
+		}
+	}
+}
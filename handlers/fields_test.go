@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/database"
+	"person-service/database/databasetest"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fieldsTestRouter() *gin.Engine {
+	person := models.Person{
+		ID:          7,
+		ExternalID:  uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+		Name:        "Jane Doe",
+		Email:       "jane@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 4, 2, 0, 0, 0, 0, time.UTC)),
+	}
+	return newTestRouter(&databasetest.PersonRepository{
+		GetByIDFunc: func(ctx context.Context, id uint, opts database.GetOptions) (models.Person, error) {
+			return person, nil
+		},
+		ListFunc: func(ctx context.Context, opts database.ListOptions) ([]models.Person, int64, error) {
+			return []models.Person{person}, 1, nil
+		},
+	})
+}
+
+func getJSON(t *testing.T, router *gin.Engine, path string) (int, map[string]any) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body), w.Body.String())
+	return w.Code, body
+}
+
+func TestGetPersonSelectsFields(t *testing.T) {
+	code, body := getJSON(t, fieldsTestRouter(), "/7?fields=name,email")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]any{
+		"external_id": "550e8400-e29b-41d4-a716-446655440000",
+		"name":        "Jane Doe",
+		"email":       "jane@example.com",
+	}, body)
+}
+
+func TestGetPersonWithoutFieldsReturnsEveryField(t *testing.T) {
+	code, body := getJSON(t, fieldsTestRouter(), "/7")
+
+	assert.Equal(t, http.StatusOK, code)
+	for _, field := range []string{"external_id", "name", "email", "date_of_birth", "age", "created_at"} {
+		assert.Contains(t, body, field)
+	}
+}
+
+func TestGetPersonRejectsUnknownField(t *testing.T) {
+	for _, fields := range []string{"name,password", "id", "XMLName"} {
+		code, body := getJSON(t, fieldsTestRouter(), "/7?fields="+fields)
+
+		assert.Equal(t, http.StatusBadRequest, code, fields)
+		assert.Contains(t, body["error"], "unknown field", fields)
+	}
+}
+
+func TestListPersonsSelectsFields(t *testing.T) {
+	code, body := getJSON(t, fieldsTestRouter(), "/persons?fields=name")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []any{map[string]any{
+		"external_id": "550e8400-e29b-41d4-a716-446655440000",
+		"name":        "Jane Doe",
+	}}, body["data"])
+	assert.Equal(t, float64(1), body["total"])
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"fmt"
+	"person-service/database"
+	"person-service/models"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// sortColumns whitelists the ?sort= keys and the columns they map to.
+var sortColumns = map[string]string{
+	"id":            "id",
+	"name":          "name",
+	"email":         "email",
+	"date_of_birth": "date_of_birth",
+	"created_at":    "created_at",
+	"updated_at":    "updated_at",
+	"last_name":     "NULLIF(last_name, '')",
+}
+
+// sortNullsLast lists the sort keys that persons may lack; those persons
+// come last in either direction.
+var sortNullsLast = map[string]bool{"last_name": true}
+
+func parsePersonFilter(c *gin.Context) (database.PersonFilter, error) {
+	filter := database.PersonFilter{
+		Name:  strings.TrimSpace(c.Query("name")),
+		Email: models.NormalizeEmail(c.Query("email")),
+		// Emails are stored lower-cased, so the domain must be too.
+		EmailDomain: strings.ToLower(strings.TrimPrefix(strings.TrimSpace(c.Query("email_domain")), "@")),
+
+		IncludeInactive: includeInactive(c),
+	}
+
+	var err error
+	if filter.BornAfter, err = parseDateParam(c, "born_after"); err != nil {
+		return filter, err
+	}
+	if filter.BornBefore, err = parseDateParam(c, "born_before"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// includeInactive reports whether the request asks to see deactivated
+// persons, which reads hide by default.
+func includeInactive(c *gin.Context) bool {
+	return c.Query("include_inactive") == "true"
+}
+
+// parseDateParam reads an optional date query parameter.
+func parseDateParam(c *gin.Context, name string) (*models.Date, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := models.ParseDate(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC3339 timestamp or YYYY-MM-DD date", name)
+	}
+	return &date, nil
+}
+
+// parseSort turns a ?sort= key such as "name" or "-date_of_birth" into an
+// ORDER BY clause, using id as a tiebreaker so paging stays stable.
+func parseSort(value string) (string, error) {
+	if value == "" {
+		return "id asc", nil
+	}
+
+	direction := "asc"
+	key := value
+	if strings.HasPrefix(value, "-") {
+		direction = "desc"
+		key = value[1:]
+	}
+
+	column, ok := sortColumns[key]
+	if !ok {
+		return "", fmt.Errorf("unknown sort key %q", key)
+	}
+	if column == "id" {
+		return "id " + direction, nil
+	}
+	if sortNullsLast[key] {
+		direction += " NULLS LAST"
+	}
+	return column + " " + direction + ", id asc", nil
+}
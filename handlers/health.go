@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"person-service/database"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const healthCheckTimeout = 2 * time.Second
+
+type HealthHandler struct {
+	db *gorm.DB
+}
+
+func NewHealthHandler(db *gorm.DB) *HealthHandler {
+	return &HealthHandler{db: db}
+}
+
+// @Summary      Liveness probe
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  map[string]string
+// @Router       /livez [get]
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Ready reports whether the database is reachable and fully migrated, so
+// no traffic is routed to an instance whose schema is still being
+// migrated.
+//
+// @Summary      Readiness probe
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  map[string]string
+// @Failure      503  {object}  map[string]string
+// @Router       /readyz [get]
+func (h *HealthHandler) Ready(c *gin.Context) {
+	if err := h.ping(c.Request.Context()); err != nil {
+		slog.WarnContext(c.Request.Context(), "Readiness check failed", "error", err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable"})
+		return
+	}
+	if err := h.checkMigrated(c.Request.Context()); err != nil {
+		slog.WarnContext(c.Request.Context(), "Readiness check failed", "error", err)
+		status := "unavailable"
+		if errors.Is(err, database.ErrNotMigrated) {
+			status = "migrating"
+		}
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": status})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func (h *HealthHandler) ping(ctx context.Context) error {
+	sqlDB, err := h.db.DB()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	return sqlDB.PingContext(ctx)
+}
+
+func (h *HealthHandler) checkMigrated(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	return database.CheckMigrated(ctx, h.db)
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"person-service/database"
+	"person-service/models"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const maxMultiGetIDs = 100
+
+// parseIDList parses a comma-separated list of numeric person IDs, dropping
+// repeats so each person is returned once.
+func parseIDList(value string) ([]uint, error) {
+	parts := strings.Split(value, ",")
+	ids := make([]uint, 0, len(parts))
+	seen := make(map[uint]bool, len(parts))
+	for _, part := range parts {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("ids must be comma-separated positive integers, got %q", part)
+		}
+		if seen[uint(id)] {
+			continue
+		}
+		seen[uint(id)] = true
+		ids = append(ids, uint(id))
+	}
+	if len(ids) > maxMultiGetIDs {
+		return nil, fmt.Errorf("ids cannot contain more than %d IDs", maxMultiGetIDs)
+	}
+	return ids, nil
+}
+
+// listPersonsByIDs serves ListPersons for ?ids=, fetching several persons in
+// one query. Filters and pagination do not apply.
+func (h *PersonHandler) listPersonsByIDs(c *gin.Context, db *gorm.DB, value string, fields map[string]bool) {
+	ids, err := parseIDList(value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid query: "+err.Error()))
+		return
+	}
+
+	var persons []models.Person
+	scope := database.PersonFilter{IncludeInactive: includeInactive(c)}.Scope
+	if err := db.Where("id IN ?", ids).Scopes(scope, database.PreloadEmails).Find(&persons).Error; err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to fetch persons by id", "error", err)
+		respondDBError(c, err, "Failed to list persons")
+		return
+	}
+
+	byID := make(map[uint]models.Person, len(persons))
+	for _, person := range persons {
+		byID[person.ID] = person
+	}
+
+	resp := models.PersonsByIDResponse{
+		Data:    make([]models.PersonResponse, 0, len(persons)),
+		Missing: []uint{},
+	}
+	for _, id := range ids {
+		person, ok := byID[id]
+		if !ok {
+			resp.Missing = append(resp.Missing, id)
+			continue
+		}
+		resp.Data = append(resp.Data, person.ToResponse())
+	}
+
+	respondPersonFields(c, http.StatusOK, resp, fields)
+}
@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"person-service/database"
+	"person-service/middleware"
+	"person-service/models"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxImportRows     = 10000
+	importLookupChunk = 500
+)
+
+var requiredImportColumns = []string{"external_id", "name", "email", "date_of_birth"}
+
+type importRow struct {
+	line int
+	req  models.SavePersonRequest
+}
+
+// ImportPersonsCSV imports persons from a multipart CSV upload (field "file")
+// with the same columns as the export. Invalid rows are reported in errors,
+// rows whose external_id already exists are skipped, and the remaining rows
+// are inserted in batches within one transaction.
+//
+// @Summary      Import persons from CSV
+// @Tags         persons
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file  formData  file  true  "CSV with external_id, name, email and date_of_birth columns"
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200   {object}  models.ImportSummary
+// @Failure      400   {object}  models.ErrorResponse
+// @Failure      401   {object}  models.ErrorResponse
+// @Failure      409   {object}  models.ErrorResponse
+// @Failure      413   {object}  models.ErrorResponse
+// @Failure      500   {object}  models.ErrorResponse
+// @Failure      503   {object}  models.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /v1/persons/import [post]
+func (h *PersonHandler) ImportPersonsCSV(c *gin.Context) {
+	db := database.Conn(c.Request.Context(), h.db)
+
+	fileHeader, err := c.FormFile("file")
+	if isBodyTooLarge(err) {
+		c.JSON(http.StatusRequestEntityTooLarge, errorResponse(c, middleware.BodyTooLargeMessage))
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request: a CSV file is required in the \"file\" field"))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request: "+err.Error()))
+		return
+	}
+	defer file.Close()
+
+	summary := models.ImportSummary{Errors: []models.ImportError{}}
+	rows, err := parseImportCSV(file, h.uniqueEmail, &summary)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid CSV: "+err.Error()))
+		return
+	}
+
+	rows = h.verifyImportEmails(c, rows, &summary)
+
+	var imported []models.Person
+	err = database.Transaction(db, func(tx *gorm.DB) error {
+		persons, err := filterExistingImportRows(tx, rows, h.uniqueEmail, &summary)
+		if err != nil {
+			return err
+		}
+		if len(persons) == 0 {
+			return nil
+		}
+		if err := tx.CreateInBatches(&persons, insertBatchChunk).Error; err != nil {
+			return err
+		}
+		if err := recordCreateAudits(tx, persons); err != nil {
+			return err
+		}
+		imported = persons
+		return nil
+	})
+	var violation *database.UniqueViolationError
+	if errors.As(err, &violation) {
+		c.JSON(http.StatusConflict, errorResponse(c, "A person in the file was created concurrently; retry the import"))
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to import persons", "error", err)
+		respondDBError(c, err, "Failed to import persons")
+		return
+	}
+
+	summary.Imported = len(imported)
+	h.publishCreated(c.Request.Context(), imported...)
+
+	slog.InfoContext(c.Request.Context(), "Imported persons", "imported", summary.Imported, "skipped", summary.Skipped, "errors", len(summary.Errors))
+	c.JSON(http.StatusOK, summary)
+}
+
+// parseImportCSV validates every data row, recording invalid rows and
+// in-file duplicates in the summary and returning the rows worth inserting.
+// Repeated emails only count as duplicates when uniqueEmail is set.
+func parseImportCSV(r io.Reader, uniqueEmail bool, summary *models.ImportSummary) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range requiredImportColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing required column %q", name)
+		}
+	}
+
+	var rows []importRow
+	seenExternalIDs := make(map[uuid.UUID]bool)
+	seenEmails := make(map[string]bool)
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			summary.Errors = append(summary.Errors, models.ImportError{Line: line, Message: err.Error()})
+			continue
+		}
+		if line-1 > maxImportRows {
+			return nil, fmt.Errorf("file cannot exceed %d rows", maxImportRows)
+		}
+
+		field := func(name string) string {
+			if i := columns[name]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		req, err := importRequest(field)
+		if err != nil {
+			summary.Errors = append(summary.Errors, models.ImportError{Line: line, Message: err.Error()})
+			continue
+		}
+
+		email := models.NormalizeEmail(req.Email)
+		if seenExternalIDs[req.ExternalID] {
+			summary.Skipped++
+			continue
+		}
+		if uniqueEmail && seenEmails[email] {
+			summary.Errors = append(summary.Errors, models.ImportError{Line: line, Message: "duplicate email within file"})
+			continue
+		}
+		seenExternalIDs[req.ExternalID] = true
+		seenEmails[email] = true
+
+		rows = append(rows, importRow{line: line, req: req})
+	}
+
+	return rows, nil
+}
+
+// verifyImportEmails drops rows whose email cannot receive mail, recording
+// them as errors.
+func (h *PersonHandler) verifyImportEmails(c *gin.Context, rows []importRow, summary *models.ImportSummary) []importRow {
+	kept := rows[:0]
+	for _, row := range rows {
+		if err := h.emails.Verify(c.Request.Context(), row.req.Email); err != nil {
+			summary.Errors = append(summary.Errors, models.ImportError{Line: row.line, Message: err.Error()})
+			continue
+		}
+		kept = append(kept, row)
+	}
+	return kept
+}
+
+func importRequest(field func(string) string) (models.SavePersonRequest, error) {
+	var req models.SavePersonRequest
+
+	externalID, err := uuid.Parse(field("external_id"))
+	if err != nil {
+		return req, errors.New("invalid external_id")
+	}
+	dateOfBirth, err := models.ParseDate(field("date_of_birth"))
+	if err != nil {
+		return req, errors.New("date_of_birth must be an RFC3339 timestamp or YYYY-MM-DD date")
+	}
+
+	req = models.SavePersonRequest{
+		ExternalID:  externalID,
+		Name:        field("name"),
+		Email:       field("email"),
+		DateOfBirth: dateOfBirth,
+	}
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		return req, err
+	}
+	if err := req.Validate(); err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+// filterExistingImportRows drops rows that collide with stored persons:
+// a known external_id counts as skipped and, when uniqueEmail is set, an
+// email owned by someone else, as theirs or one of their other addresses,
+// is reported as an error.
+func filterExistingImportRows(tx *gorm.DB, rows []importRow, uniqueEmail bool, summary *models.ImportSummary) ([]models.Person, error) {
+	existingExternalIDs := make(map[uuid.UUID]bool)
+	existingEmails := make(map[string]bool)
+
+	for start := 0; start < len(rows); start += importLookupChunk {
+		end := min(start+importLookupChunk, len(rows))
+
+		externalIDs := make([]uuid.UUID, 0, end-start)
+		emails := make([]string, 0, end-start)
+		for _, row := range rows[start:end] {
+			externalIDs = append(externalIDs, row.req.ExternalID)
+			emails = append(emails, models.NormalizeEmail(row.req.Email))
+		}
+
+		var existing []models.Person
+		if err := tx.Select("external_id").Where("external_id IN ?", externalIDs).Find(&existing).Error; err != nil {
+			return nil, err
+		}
+		for _, person := range existing {
+			existingExternalIDs[person.ExternalID] = true
+		}
+		if !uniqueEmail {
+			continue
+		}
+		taken, err := database.TakenEmails(tx, emails, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, email := range taken {
+			existingEmails[email] = true
+		}
+	}
+
+	persons := make([]models.Person, 0, len(rows))
+	for _, row := range rows {
+		switch {
+		case existingExternalIDs[row.req.ExternalID]:
+			summary.Skipped++
+		case existingEmails[models.NormalizeEmail(row.req.Email)]:
+			summary.Errors = append(summary.Errors, models.ImportError{Line: row.line, Message: "Person with this email already exists"})
+		default:
+			persons = append(persons, models.FromSaveRequest(row.req))
+		}
+	}
+	return persons, nil
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"person-service/database"
+	"person-service/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// LookupPersons resolves a batch of external IDs in one query, for clients
+// syncing from a system that only knows the UUIDs. Repeated IDs are returned
+// once; IDs with no person are listed under missing.
+//
+// @Summary      Look up persons by external ID
+// @Tags         persons
+// @Accept       json
+// @Produce      json
+// @Produce      xml
+// @Param        lookup            body      models.LookupRequest  true   "Up to 100 external IDs"
+// @Param        fields            query     string                false  "Comma-separated fields to return, e.g. name,email; external_id is always included"
+// @Param        include_inactive  query     bool                  false  "Also return deactivated persons"
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200               {object}  models.LookupResponse
+// @Failure      400               {object}  models.ErrorResponse
+// @Failure      413               {object}  models.ErrorResponse
+// @Failure      415               {object}  models.ErrorResponse
+// @Failure      500               {object}  models.ErrorResponse
+// @Failure      503               {object}  models.ErrorResponse
+// @Router       /v1/persons/lookup [post]
+func (h *PersonHandler) LookupPersons(c *gin.Context) {
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
+	fields, err := parseFields(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid query: "+err.Error()))
+		return
+	}
+
+	var req models.LookupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	ids := uniqueExternalIDs(req.ExternalIDs)
+	if len(ids) == 0 {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid request: external_ids must contain at least one ID"))
+		return
+	}
+	if len(ids) > maxMultiGetIDs {
+		c.JSON(http.StatusBadRequest, errorResponse(c, fmt.Sprintf("Invalid request: external_ids cannot contain more than %d IDs", maxMultiGetIDs)))
+		return
+	}
+
+	var persons []models.Person
+	scope := database.PersonFilter{IncludeInactive: includeInactive(c)}.Scope
+	if err := database.Conn(ctx, h.db).Where("external_id IN ?", ids).Scopes(scope, database.PreloadEmails).Find(&persons).Error; err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to look up persons by external id", "error", err)
+		respondDBError(c, err, "Failed to look up persons")
+		return
+	}
+
+	byExternalID := make(map[uuid.UUID]models.Person, len(persons))
+	for _, person := range persons {
+		byExternalID[person.ExternalID] = person
+	}
+
+	resp := models.LookupResponse{
+		Data:    make([]models.PersonResponse, 0, len(persons)),
+		Missing: []uuid.UUID{},
+	}
+	for _, id := range ids {
+		person, ok := byExternalID[id]
+		if !ok {
+			resp.Missing = append(resp.Missing, id)
+			continue
+		}
+		resp.Data = append(resp.Data, person.ToResponse())
+	}
+
+	respondPersonFields(c, http.StatusOK, resp, fields)
+}
+
+// uniqueExternalIDs drops repeated IDs, keeping the first occurrence.
+func uniqueExternalIDs(ids []uuid.UUID) []uuid.UUID {
+	unique := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+	"person-service/database/databasetest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func lookupTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := NewPersonHandler(nil, WithRepository(&databasetest.PersonRepository{}))
+
+	router := gin.New()
+	router.POST("/persons/lookup", h.LookupPersons)
+	return router
+}
+
+func TestLookupPersonsRejectsOversizedBatch(t *testing.T) {
+	ids := make([]uuid.UUID, maxMultiGetIDs+1)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+
+	code, resp := serve(t, lookupTestRouter(), http.MethodPost, "/persons/lookup", map[string]any{"external_ids": ids})
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "Invalid request: external_ids cannot contain more than 100 IDs", resp.Error)
+}
+
+func TestLookupPersonsRejectsInvalidIDs(t *testing.T) {
+	for _, body := range []any{
+		map[string]any{"external_ids": []string{"not-a-uuid"}},
+		map[string]any{"external_ids": []string{}},
+		map[string]any{},
+	} {
+		code, _ := serve(t, lookupTestRouter(), http.MethodPost, "/persons/lookup", body)
+
+		assert.Equal(t, http.StatusBadRequest, code, body)
+	}
+}
+
+func TestUniqueExternalIDsKeepsFirstOccurrence(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+
+	assert.Equal(t, []uuid.UUID{a, b}, uniqueExternalIDs([]uuid.UUID{a, b, a, b}))
+}
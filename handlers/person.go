@@ -1,87 +1,848 @@
 package handlers
 
 import (
+	"context"
 	"errors"
-	"log"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"person-service/cache"
+	"person-service/database"
+	"person-service/emailcheck"
+	"person-service/events"
+	"person-service/middleware"
 	"person-service/models"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+const (
+	defaultPage        = 1
+	defaultPageSize    = 20
+	defaultMaxPageSize = 100
+
+	defaultQueryTimeout = 5 * time.Second
+
+	idempotencyKeyHeader    = "Idempotency-Key"
+	maxIdempotencyKeyLength = 255
+)
+
+// PersonHandler serves the person endpoints. The core CRUD and list
+// operations go through persons; the remaining endpoints query db directly.
 type PersonHandler struct {
-	db *gorm.DB
+	db           *gorm.DB
+	persons      database.PersonRepository
+	queryTimeout time.Duration
+	pageSize     int
+	maxPageSize  int
+	publisher    events.PersonEventPublisher
+	notifier     events.PersonNotifier
+	cache        cache.PersonCache
+	emails       emailcheck.Verifier
+	changes      *events.Broker[events.PersonChange]
+	created      *events.Broker[models.Person]
+	uniqueEmail  bool
+}
+
+type Option func(*PersonHandler)
+
+// WithQueryTimeout bounds how long each request may spend on database calls.
+func WithQueryTimeout(timeout time.Duration) Option {
+	return func(h *PersonHandler) {
+		h.queryTimeout = timeout
+	}
+}
+
+// WithPageSizes sets the page size used when a request gives none and the
+// largest one a request may ask for; larger requests are capped.
+func WithPageSizes(pageSize, maxPageSize int) Option {
+	return func(h *PersonHandler) {
+		h.pageSize = pageSize
+		h.maxPageSize = maxPageSize
+	}
+}
+
+// WithPublisher sets the publisher notified after persons are created.
+func WithPublisher(publisher events.PersonEventPublisher) Option {
+	return func(h *PersonHandler) {
+		h.publisher = publisher
+	}
+}
+
+// WithNotifier sets the notifier told about every create, update and
+// delete, such as the webhook dispatcher.
+func WithNotifier(notifier events.PersonNotifier) Option {
+	return func(h *PersonHandler) {
+		h.notifier = notifier
+	}
+}
+
+// WithCache sets the cache consulted by GetPerson for numeric IDs. Updates
+// and deletes evict the affected entry.
+func WithCache(c cache.PersonCache) Option {
+	return func(h *PersonHandler) {
+		h.cache = c
+	}
+}
+
+// WithRepository replaces the GORM-backed repository used for the core
+// CRUD operations, e.g. with a mock in tests.
+func WithRepository(persons database.PersonRepository) Option {
+	return func(h *PersonHandler) {
+		h.persons = persons
+	}
+}
+
+// WithEmailVerifier sets the check that saved emails can receive mail.
+func WithEmailVerifier(verifier emailcheck.Verifier) Option {
+	return func(h *PersonHandler) {
+		h.emails = verifier
+	}
+}
+
+// WithChangeFeed sets the broker whose person changes StreamPersonChanges
+// forwards to clients. Without it the stream stays silent.
+func WithChangeFeed(changes *events.Broker[events.PersonChange]) Option {
+	return func(h *PersonHandler) {
+		h.changes = changes
+	}
+}
+
+// WithCreatedFeed sets the broker whose created persons StreamCreatedPersons
+// forwards to clients. The publisher must feed it, e.g. through an
+// events.BrokerPublisher; without it the stream stays silent.
+func WithCreatedFeed(created *events.Broker[models.Person]) Option {
+	return func(h *PersonHandler) {
+		h.created = created
+	}
+}
+
+// WithUniqueEmail sets whether writes reject an email another person of
+// the tenant already has, as its email or one of its other addresses. It
+// must match the email index checked by database.CheckEmailUniqueness, which
+// covers primary emails only; the other addresses are checked on every
+// write. It does not affect a repository set with WithRepository.
+func WithUniqueEmail(unique bool) Option {
+	return func(h *PersonHandler) {
+		h.uniqueEmail = unique
+	}
+}
+
+func NewPersonHandler(db *gorm.DB, opts ...Option) *PersonHandler {
+	h := &PersonHandler{
+		db:           db,
+		queryTimeout: defaultQueryTimeout,
+		pageSize:     defaultPageSize,
+		maxPageSize:  defaultMaxPageSize,
+		publisher:    events.NoopPublisher{},
+		notifier:     events.NoopNotifier{},
+		cache:        cache.NoopCache{},
+		emails:       emailcheck.NoopVerifier{},
+		changes:      events.NewBroker[events.PersonChange](0),
+		created:      events.NewBroker[models.Person](0),
+		uniqueEmail:  true,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	if h.persons == nil {
+		h.persons = database.NewPersonRepository(db, h.uniqueEmail)
+	}
+	return h
+}
+
+// queryContext returns the request context with the configured query
+// timeout applied.
+func (h *PersonHandler) queryContext(c *gin.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(c.Request.Context(), h.queryTimeout)
 }
 
-func NewPersonHandler(db *gorm.DB) *PersonHandler {
-	return &PersonHandler{db: db}
+// queryDB returns a database handle bound to the request context with the
+// configured query timeout applied.
+func (h *PersonHandler) queryDB(c *gin.Context) (*gorm.DB, context.CancelFunc) {
+	ctx, cancel := h.queryContext(c)
+	return database.Conn(ctx, h.db), cancel
 }
 
+// @Summary      Create person
+// @Tags         persons
+// @Accept       json
+// @Produce      json
+// @Param        person           body    models.SavePersonRequest  true   "Person to create"
+// @Param        Idempotency-Key  header  string                    false  "Replays return the originally created person"
+// @Param        upsert           query   bool                      false  "Update the person with the same external_id instead of failing with 409"
+// @Param        dry_run          query   bool                      false  "Validate and check for duplicates without saving; responds 200 with the would-be person"
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      201  {object}  models.PersonResponse
+// @Header       201  {string}  Location  "URL of the created person by external ID"
+// @Success      200  {object}  models.PersonResponse  "Idempotent replay, upsert of an existing person or dry run"
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      413  {object}  models.ErrorResponse
+// @Failure      415  {object}  models.ErrorResponse
+// @Failure      422  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Failure      503  {object}  models.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /v1/save [post]
 func (h *PersonHandler) SavePerson(c *gin.Context) {
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
 	var req models.SavePersonRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error: "Invalid request: " + err.Error(),
-		})
+		respondBindError(c, err)
 		return
 	}
 
 	if err := req.Validate(); err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error: "Validation error: " + err.Error(),
-		})
+		respondValidationError(c, err)
+		return
+	}
+	if !h.verifyEmail(c, req.PrimaryEmail()) {
 		return
 	}
 
-	var existingPerson models.Person
-	if err := h.db.Where("external_id = ?", req.ExternalID).First(&existingPerson).Error; err == nil {
-		c.JSON(http.StatusConflict, models.ErrorResponse{
-			Error: "Person with this external_id already exists",
-		})
+	idempotencyKey := c.GetHeader(idempotencyKeyHeader)
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		c.JSON(http.StatusBadRequest, errorResponse(c, fmt.Sprintf("Idempotency-Key cannot exceed %d characters", maxIdempotencyKeyLength)))
 		return
 	}
 
+	// Duplicates are left to the unique indexes and, for the addresses the
+	// email index does not cover, to the repository's check.
 	person := models.FromSaveRequest(req)
+	if c.Query("dry_run") == "true" {
+		h.dryRunPerson(c, database.Conn(ctx, h.db), person)
+		return
+	}
+	if c.Query("upsert") == "true" {
+		// Repeating an upsert converges on the same state, so the
+		// Idempotency-Key is not needed there.
+		h.upsertPerson(c, database.Conn(ctx, h.db), person)
+		return
+	}
+	if idempotencyKey != "" {
+		person.IdempotencyKey = &idempotencyKey
+	}
 
-	if err := h.db.Create(&person).Error; err != nil {
-		log.Printf("Failed to create person: %v", err)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error: "Failed to save person",
-		})
+	err := h.persons.Create(ctx, &person)
+	var violation *database.UniqueViolationError
+	if errors.As(err, &violation) && idempotencyKey != "" {
+		// A repeated key may trip any of the indexes first, so look for the
+		// original request before reporting a conflict.
+		existingPerson, lookupErr := h.persons.GetByIdempotencyKey(ctx, idempotencyKey)
+		if lookupErr == nil {
+			slog.InfoContext(c.Request.Context(), "Replayed idempotent create", "person_id", existingPerson.ID, "external_id", existingPerson.ExternalID)
+			c.JSON(http.StatusOK, existingPerson.ToResponse())
+			return
+		}
+		if !errors.Is(lookupErr, database.ErrPersonNotFound) {
+			slog.ErrorContext(c.Request.Context(), "Database error checking idempotency key", "error", lookupErr)
+			respondDBError(c, lookupErr, "Failed to save person")
+			return
+		}
+	}
+
+	switch {
+	case isUniqueViolation(err, models.ExternalIDIndex):
+		c.JSON(http.StatusConflict, errorResponse(c, "Person with this external_id already exists"))
+		return
+	case isUniqueViolation(err, models.EmailIndex):
+		c.JSON(http.StatusConflict, errorResponse(c, "Person with this email already exists"))
+		return
+	case err != nil:
+		slog.ErrorContext(c.Request.Context(), "Failed to create person", "external_id", person.ExternalID, "error", err)
+		respondDBError(c, err, "Failed to save person")
 		return
 	}
 
-	log.Printf("Created person with ID: %d, ExternalID: %s", person.ID, person.ExternalID)
+	slog.InfoContext(c.Request.Context(), "Created person", "person_id", person.ID, "external_id", person.ExternalID)
+	h.publishCreated(c.Request.Context(), person)
+	setLocation(c, person)
 	c.JSON(http.StatusCreated, person.ToResponse())
 }
 
+// setLocation points the Location header at the created person by its
+// external ID, the identifier clients know it by. The URL is a sibling of
+// the matched /save route, so it keeps the base path and API version the
+// client used.
+func setLocation(c *gin.Context, person models.Person) {
+	prefix := strings.TrimSuffix(c.FullPath(), "/save")
+	c.Header("Location", prefix+"/"+person.ExternalID.String())
+}
+
+// @Summary      Get person by ID or external ID
+// @Description  The id may be the numeric person ID or the external UUID.
+// @Description  Send Accept: application/xml for XML or text/vcard for a vCard.
+// @Tags         persons
+// @Produce      json
+// @Produce      xml
+// @Produce      text/vcard
+// @Param        id               path      string  true   "Person ID or external ID"
+// @Param        include_deleted  query     bool    false  "Include soft-deleted persons"
+// @Param        include_inactive query     bool    false  "Include deactivated persons"
+// @Param        fields           query     string  false  "Comma-separated response fields, e.g. name,email; external_id is always included"
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200              {object}  models.PersonResponse
+// @Failure      400              {object}  models.ErrorResponse
+// @Failure      404              {object}  models.ErrorResponse
+// @Failure      500              {object}  models.ErrorResponse
+// @Failure      503              {object}  models.ErrorResponse
+// @Router       /v1/{id} [get]
 func (h *PersonHandler) GetPerson(c *gin.Context) {
+	fields, err := parseFields(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid query: "+err.Error()))
+		return
+	}
+
+	person, ok := h.findPerson(c, c.Param("id"), database.GetOptions{
+		IncludeDeleted:  c.Query("include_deleted") == "true",
+		IncludeInactive: includeInactive(c),
+	})
+	if !ok {
+		return
+	}
+
+	if c.NegotiateFormat(gin.MIMEJSON, gin.MIMEXML, gin.MIMEXML2, vcardContentType) == vcardContentType {
+		renderVCard(c, person)
+		return
+	}
+	respondPersonFields(c, http.StatusOK, person.ToResponse(), fields)
+}
+
+// findPerson looks a person up by numeric ID, served from the cache when
+// possible, or by external UUID. On failure it writes the error response
+// and returns false.
+func (h *PersonHandler) findPerson(c *gin.Context, idStr string, opts database.GetOptions) (models.Person, bool) {
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
+	var numericID uint
+	var person models.Person
+	var err error
+	if id, parseErr := strconv.ParseUint(idStr, 10, 32); parseErr == nil {
+		numericID = uint(id)
+		if !opts.IncludeDeleted {
+			// The cache is shared by all tenants; a hit for another tenant's
+			// person, or a deactivated one, falls through to the scoped query
+			// and its 404.
+			if person, ok := h.cache.Get(numericID); ok && ownedByRequestTenant(c, person) && (person.Active || opts.IncludeInactive) {
+				return person, true
+			}
+		}
+		person, err = h.persons.GetByID(ctx, numericID, opts)
+	} else if externalID, parseErr := uuid.Parse(idStr); parseErr == nil {
+		person, err = h.persons.GetByExternalID(ctx, externalID, opts)
+	} else {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid ID format"))
+		return models.Person{}, false
+	}
+
+	if err != nil {
+		if errors.Is(err, database.ErrPersonNotFound) {
+			c.JSON(http.StatusNotFound, errorResponse(c, "Person not found"))
+			return models.Person{}, false
+		}
+		slog.ErrorContext(c.Request.Context(), "Database error retrieving person", "id", idStr, "error", err)
+		respondDBError(c, err, "Failed to retrieve person")
+		return models.Person{}, false
+	}
+
+	if numericID != 0 && !person.DeletedAt.Valid {
+		h.cache.Set(person)
+	}
+	return person, true
+}
+
+// @Summary      Get person by external ID
+// @Tags         persons
+// @Produce      json
+// @Param        external_id  path      string  true  "External ID"  format(uuid)
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200          {object}  models.PersonResponse
+// @Failure      400          {object}  models.ErrorResponse
+// @Failure      404          {object}  models.ErrorResponse
+// @Failure      500          {object}  models.ErrorResponse
+// @Failure      503          {object}  models.ErrorResponse
+// @Router       /v1/persons/by-external/{external_id} [get]
+func (h *PersonHandler) GetPersonByExternalID(c *gin.Context) {
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
+	externalID, err := uuid.Parse(c.Param("external_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid ID format"))
+		return
+	}
+
+	person, err := h.persons.GetByExternalID(ctx, externalID, database.GetOptions{IncludeInactive: includeInactive(c)})
+	if err != nil {
+		if errors.Is(err, database.ErrPersonNotFound) {
+			c.JSON(http.StatusNotFound, errorResponse(c, "Person not found"))
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "Database error retrieving person", "external_id", externalID, "error", err)
+		respondDBError(c, err, "Failed to retrieve person")
+		return
+	}
+
+	c.JSON(http.StatusOK, person.ToResponse())
+}
+
+// @Summary      Update person
+// @Tags         persons
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                         true   "Person ID"
+// @Param        If-Match  header    string                      false  "Expected version"
+// @Param        person    body      models.UpdatePersonRequest  true   "New person data"
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200       {object}  models.PersonResponse
+// @Failure      400       {object}  models.ErrorResponse
+// @Failure      401       {object}  models.ErrorResponse
+// @Failure      404       {object}  models.ErrorResponse
+// @Failure      409       {object}  models.ErrorResponse
+// @Failure      413       {object}  models.ErrorResponse
+// @Failure      415       {object}  models.ErrorResponse
+// @Failure      422       {object}  models.ErrorResponse
+// @Failure      500       {object}  models.ErrorResponse
+// @Failure      503       {object}  models.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /v1/{id} [put]
+func (h *PersonHandler) UpdatePerson(c *gin.Context) {
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error: "Invalid ID format",
-		})
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid ID format"))
 		return
 	}
 
-	var person models.Person
-	if err := h.db.First(&person, uint(id)).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error: "Person not found",
-			})
+	var req models.UpdatePersonRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+	if !h.verifyEmail(c, req.PrimaryEmail()) {
+		return
+	}
+
+	// Read from the primary so the version check sees the latest write.
+	person, err := h.persons.GetByID(ctx, uint(id), database.GetOptions{FromPrimary: true, IncludeInactive: true})
+	if err != nil {
+		if errors.Is(err, database.ErrPersonNotFound) {
+			c.JSON(http.StatusNotFound, errorResponse(c, "Person not found"))
 			return
 		}
-		log.Printf("Database error retrieving person ID %d: %v", id, err)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error: "Failed to retrieve person",
-		})
+		slog.ErrorContext(c.Request.Context(), "Database error retrieving person", "person_id", id, "error", err)
+		respondDBError(c, err, "Failed to retrieve person")
+		return
+	}
+
+	if !checkVersionPrecondition(c, person) {
+		return
+	}
+
+	err = h.persons.Update(ctx, &person, req.Changes())
+	switch {
+	case isUniqueViolation(err, models.EmailIndex):
+		c.JSON(http.StatusConflict, errorResponse(c, "Person with this email already exists"))
+		return
+	case errors.Is(err, database.ErrVersionConflict):
+		c.JSON(http.StatusConflict, errorResponse(c, "Person was modified concurrently"))
+		return
+	case err != nil:
+		slog.ErrorContext(c.Request.Context(), "Failed to update person", "person_id", id, "error", err)
+		respondDBError(c, err, "Failed to update person")
+		return
+	}
+
+	database.AfterCommit(c.Request.Context(), func() {
+		h.cache.Delete(person.ID)
+		h.notifier.Notify(c.Request.Context(), events.PersonUpdated, person)
+	})
+	slog.InfoContext(c.Request.Context(), "Updated person", "person_id", person.ID, "external_id", person.ExternalID)
+	c.JSON(http.StatusOK, person.ToResponse())
+}
+
+// @Summary      Delete person
+// @Tags         persons
+// @Param        id   path  int  true  "Person ID"
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      204
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Failure      503  {object}  models.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /v1/{id} [delete]
+func (h *PersonHandler) DeletePerson(c *gin.Context) {
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid ID format"))
+		return
+	}
+
+	person, err := h.persons.Delete(ctx, uint(id))
+	if errors.Is(err, database.ErrPersonNotFound) {
+		c.JSON(http.StatusNotFound, errorResponse(c, "Person not found"))
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to delete person", "person_id", id, "error", err)
+		respondDBError(c, err, "Failed to delete person")
+		return
+	}
+
+	database.AfterCommit(c.Request.Context(), func() {
+		h.cache.Delete(uint(id))
+		h.notifier.Notify(c.Request.Context(), events.PersonDeleted, person)
+	})
+	slog.InfoContext(c.Request.Context(), "Deleted person", "person_id", id)
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      List persons
+// @Description  Offset mode uses page and page_size. Passing cursor or limit switches to
+// @Description  cursor mode, ordered by id, which returns a models.PersonCursorResponse.
+// @Description  Passing ids fetches up to 100 persons by numeric ID in the given order,
+// @Description  returning a models.PersonsByIDResponse; filters and pagination are ignored.
+// @Description  Send Accept: application/xml for XML.
+// @Tags         persons
+// @Produce      json
+// @Produce      xml
+// @Param        page         query     int     false  "Page number"
+// @Param        page_size    query     int     false  "Page size (capped at MAX_PAGE_SIZE, 100 by default)"
+// @Param        cursor       query     string  false  "Opaque next_cursor from the previous page"
+// @Param        limit        query     int     false  "Cursor page size (capped at MAX_PAGE_SIZE, 100 by default)"
+// @Param        name         query     string  false  "Case- and accent-insensitive name substring"
+// @Param        email        query     string  false  "Exact email"
+// @Param        email_domain query     string  false  "Exact email domain, e.g. example.com"
+// @Param        born_after   query     string  false  "RFC3339 timestamp or YYYY-MM-DD"
+// @Param        born_before  query     string  false  "RFC3339 timestamp or YYYY-MM-DD"
+// @Param        include_inactive  query  bool  false  "Include deactivated persons"
+// @Param        sort         query     string  false  "Sort key, prefix with - for descending"
+// @Param        ids          query     string  false  "Comma-separated numeric IDs, e.g. 1,2,3"
+// @Param        fields       query     string  false  "Comma-separated response fields, e.g. name,email; external_id is always included"
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200          {object}  models.PersonListResponse
+// @Failure      400          {object}  models.ErrorResponse
+// @Failure      500          {object}  models.ErrorResponse
+// @Failure      503          {object}  models.ErrorResponse
+// @Router       /v1/persons [get]
+func (h *PersonHandler) ListPersons(c *gin.Context) {
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
+	fields, err := parseFields(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid query: "+err.Error()))
+		return
+	}
+
+	if ids, ok := c.GetQuery("ids"); ok {
+		h.listPersonsByIDs(c, database.Conn(ctx, h.db), ids, fields)
+		return
+	}
+
+	filter, err := parsePersonFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid query: "+err.Error()))
+		return
+	}
+
+	if c.Query("cursor") != "" || c.Query("limit") != "" {
+		h.listPersonsByCursor(c, database.Conn(ctx, h.db), filter, fields)
+		return
+	}
+
+	page := parsePositiveInt(c.Query("page"), defaultPage)
+	pageSize := h.parsePageSize(c, "page_size")
+
+	order, err := parseSort(c.Query("sort"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid query: "+err.Error()))
+		return
+	}
+
+	persons, total, err := h.persons.List(ctx, database.ListOptions{
+		Filter:      filter,
+		Order:       order,
+		Limit:       pageSize,
+		Offset:      (page - 1) * pageSize,
+		WindowTotal: true,
+	})
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to list persons", "error", err)
+		respondDBError(c, err, "Failed to list persons")
+		return
+	}
+
+	data := make([]models.PersonResponse, 0, len(persons))
+	for _, person := range persons {
+		data = append(data, person.ToResponse())
+	}
+
+	respondPersonFields(c, http.StatusOK, models.PersonListResponse{
+		Data:     data,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	}, fields)
+}
+
+// @Summary      Count persons
+// @Tags         persons
+// @Produce      json
+// @Param        name         query     string  false  "Case- and accent-insensitive name substring"
+// @Param        email        query     string  false  "Exact email"
+// @Param        email_domain query     string  false  "Exact email domain, e.g. example.com"
+// @Param        born_after   query     string  false  "RFC3339 timestamp or YYYY-MM-DD"
+// @Param        born_before  query     string  false  "RFC3339 timestamp or YYYY-MM-DD"
+// @Param        include_inactive  query  bool  false  "Include deactivated persons"
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200          {object}  models.CountResponse
+// @Failure      400          {object}  models.ErrorResponse
+// @Failure      500          {object}  models.ErrorResponse
+// @Failure      503          {object}  models.ErrorResponse
+// @Router       /v1/persons/count [get]
+func (h *PersonHandler) CountPersons(c *gin.Context) {
+	db, cancel := h.queryDB(c)
+	defer cancel()
+
+	filter, err := parsePersonFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid query: "+err.Error()))
+		return
+	}
+
+	var count int64
+	if err := db.Model(&models.Person{}).Scopes(filter.Scope).Count(&count).Error; err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to count persons", "error", err)
+		respondDBError(c, err, "Failed to count persons")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.CountResponse{Count: count})
+}
+
+// respondNegotiated writes data as XML when the client's Accept header
+// prefers it and as JSON otherwise, including when nothing matches.
+func respondNegotiated(c *gin.Context, code int, data any) {
+	switch c.NegotiateFormat(gin.MIMEJSON, gin.MIMEXML, gin.MIMEXML2) {
+	case gin.MIMEXML, gin.MIMEXML2:
+		c.XML(code, data)
+	default:
+		c.JSON(code, data)
+	}
+}
+
+// verifyEmail responds 422 and returns false when email cannot receive mail.
+func (h *PersonHandler) verifyEmail(c *gin.Context, email string) bool {
+	if err := h.emails.Verify(c.Request.Context(), email); err != nil {
+		respondValidationError(c, err)
+		return false
+	}
+	return true
+}
+
+func errorResponse(c *gin.Context, message string) models.ErrorResponse {
+	return models.ErrorResponse{
+		Error:     message,
+		RequestID: c.GetString(middleware.RequestIDKey),
+	}
+}
+
+// ownedByRequestTenant reports whether person belongs to the request's
+// tenant. Requests without a tenant are unscoped, like their queries.
+func ownedByRequestTenant(c *gin.Context, person models.Person) bool {
+	tenant, ok := database.TenantFromContext(c.Request.Context())
+	return !ok || person.TenantID == tenant
+}
+
+// publishCreated notifies the notifier and the publisher, in one batch, of
+// the created persons once they are committed; a failure is logged but does
+// not fail the request since the persons have already been stored.
+func (h *PersonHandler) publishCreated(ctx context.Context, persons ...models.Person) {
+	database.AfterCommit(ctx, func() {
+		for _, person := range persons {
+			h.notifier.Notify(ctx, events.PersonCreated, person)
+		}
+		if err := h.publisher.PublishCreated(ctx, persons...); err != nil {
+			slog.ErrorContext(ctx, "Failed to publish person created events", "persons", len(persons), "error", err)
+		}
+	})
+}
+
+// checkVersionPrecondition compares an optional If-Match header (the expected
+// version, quoted or not) with the stored version. It writes a 400 or 409
+// response and returns false when the request must not proceed.
+func checkVersionPrecondition(c *gin.Context, person models.Person) bool {
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		return true
+	}
+
+	expected, err := strconv.Atoi(strings.Trim(ifMatch, `"`))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid If-Match header: expected a version number"))
+		return false
+	}
+	if expected != person.Version {
+		c.JSON(http.StatusConflict, errorResponse(c, fmt.Sprintf("Version mismatch: expected %d, current is %d", expected, person.Version)))
+		return false
+	}
+	return true
+}
+
+// isUniqueViolation reports whether err is a unique violation of the named
+// index.
+func isUniqueViolation(err error, index string) bool {
+	var violation *database.UniqueViolationError
+	return errors.As(err, &violation) && violation.Constraint == index
+}
+
+// respondDBError answers 503 when the database call ran out of time (or the
+// request was cancelled) and 500 with the given message otherwise.
+func respondDBError(c *gin.Context, err error, message string) {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		c.JSON(http.StatusServiceUnavailable, errorResponse(c, "Database request timed out"))
+		return
+	}
+	c.JSON(http.StatusInternalServerError, errorResponse(c, message))
+}
+
+func parsePositiveInt(value string, fallback int) int {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return fallback
+	}
+	return n
+}
+
+// parsePageSize reads the page size from the named query parameter, using
+// the configured default when it is missing or malformed and capping it at
+// the configured maximum.
+func (h *PersonHandler) parsePageSize(c *gin.Context, param string) int {
+	return min(parsePositiveInt(c.Query(param), h.pageSize), h.maxPageSize)
+}
+
+// @Summary      Partially update person
+// @Tags         persons
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                        true   "Person ID"
+// @Param        If-Match  header    string                     false  "Expected version"
+// @Param        person    body      models.PatchPersonRequest  true   "Fields to change"
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200       {object}  models.PersonResponse
+// @Failure      400       {object}  models.ErrorResponse
+// @Failure      401       {object}  models.ErrorResponse
+// @Failure      404       {object}  models.ErrorResponse
+// @Failure      409       {object}  models.ErrorResponse
+// @Failure      413       {object}  models.ErrorResponse
+// @Failure      415       {object}  models.ErrorResponse
+// @Failure      422       {object}  models.ErrorResponse
+// @Failure      500       {object}  models.ErrorResponse
+// @Failure      503       {object}  models.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /v1/{id} [patch]
+func (h *PersonHandler) PatchPerson(c *gin.Context) {
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid ID format"))
+		return
+	}
+
+	var req models.PatchPersonRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+	if req.Email != nil && !h.verifyEmail(c, *req.Email) {
+		return
+	}
+
+	// Read from the primary so the version check sees the latest write.
+	person, err := h.persons.GetByID(ctx, uint(id), database.GetOptions{FromPrimary: true, IncludeInactive: true})
+	if err != nil {
+		if errors.Is(err, database.ErrPersonNotFound) {
+			c.JSON(http.StatusNotFound, errorResponse(c, "Person not found"))
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "Database error retrieving person", "person_id", id, "error", err)
+		respondDBError(c, err, "Failed to retrieve person")
+		return
+	}
+
+	if !checkVersionPrecondition(c, person) {
+		return
+	}
+
+	changes := req.Changes()
+	name, derived, err := req.DerivedName(person)
+	if err != nil {
+		respondValidationError(c, err)
+		return
+	}
+	if derived {
+		changes["name"] = name
+	}
+
+	err = h.persons.Update(ctx, &person, changes)
+	switch {
+	case isUniqueViolation(err, models.EmailIndex):
+		c.JSON(http.StatusConflict, errorResponse(c, "Person with this email already exists"))
+		return
+	case errors.Is(err, database.ErrVersionConflict):
+		c.JSON(http.StatusConflict, errorResponse(c, "Person was modified concurrently"))
+		return
+	case err != nil:
+		slog.ErrorContext(c.Request.Context(), "Failed to update person", "person_id", id, "error", err)
+		respondDBError(c, err, "Failed to update person")
 		return
 	}
 
+	database.AfterCommit(c.Request.Context(), func() {
+		h.cache.Delete(person.ID)
+		h.notifier.Notify(c.Request.Context(), events.PersonUpdated, person)
+	})
+	slog.InfoContext(c.Request.Context(), "Patched person", "person_id", person.ID, "external_id", person.ExternalID)
 	c.JSON(http.StatusOK, person.ToResponse())
 }
@@ -0,0 +1,414 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"person-service/database"
+	"person-service/database/databasetest"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errDatabaseDown = errors.New("connection refused")
+
+func newTestRouter(repo *databasetest.PersonRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := NewPersonHandler(nil, WithRepository(repo))
+
+	router := gin.New()
+	router.GET("/persons", h.ListPersons)
+	router.DELETE("/persons", h.DeletePersons)
+	router.POST("/save", h.SavePerson)
+	router.GET("/:id", h.GetPerson)
+	router.PUT("/:id", h.UpdatePerson)
+	router.DELETE("/:id", h.DeletePerson)
+	return router
+}
+
+func serve(t *testing.T, router *gin.Engine, method, path string, body any) (int, models.ErrorResponse) {
+	t.Helper()
+
+	var reader *bytes.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(jsonBody)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var response models.ErrorResponse
+	if w.Code >= http.StatusBadRequest {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response), w.Body.String())
+	}
+	return w.Code, response
+}
+
+func validSaveRequest() models.SavePersonRequest {
+	return models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Jane Doe",
+		Email:       "jane@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+}
+
+func TestSavePersonCreates(t *testing.T) {
+	var created models.Person
+	router := newTestRouter(&databasetest.PersonRepository{
+		CreateFunc: func(ctx context.Context, person *models.Person) error {
+			person.ID = 1
+			created = *person
+			return nil
+		},
+	})
+
+	code, _ := serve(t, router, http.MethodPost, "/save", validSaveRequest())
+
+	assert.Equal(t, http.StatusCreated, code)
+	assert.Equal(t, uint(1), created.ID)
+	assert.Equal(t, "jane@example.com", created.Email)
+}
+
+func TestSavePersonSetsLocation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewPersonHandler(nil, WithRepository(&databasetest.PersonRepository{
+		CreateFunc: func(ctx context.Context, person *models.Person) error {
+			return nil
+		},
+	}))
+	router := gin.New()
+	router.POST("/person-service/v1/save", h.SavePerson)
+
+	saveReq := validSaveRequest()
+	jsonBody, err := json.Marshal(saveReq)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/person-service/v1/save", bytes.NewReader(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/person-service/v1/"+saveReq.ExternalID.String(), w.Header().Get("Location"))
+}
+
+func TestSavePersonWithStructuredNames(t *testing.T) {
+	var created models.Person
+	router := newTestRouter(&databasetest.PersonRepository{
+		CreateFunc: func(ctx context.Context, person *models.Person) error {
+			created = *person
+			return nil
+		},
+	})
+	req := validSaveRequest()
+	req.Name = ""
+	req.FirstName = "Jane"
+	req.LastName = "Doe"
+
+	code, _ := serve(t, router, http.MethodPost, "/save", req)
+
+	assert.Equal(t, http.StatusCreated, code)
+	assert.Equal(t, "Jane Doe", created.Name)
+	assert.Equal(t, "Doe", created.LastName)
+}
+
+func TestSavePersonRequiresNameOrParts(t *testing.T) {
+	router := newTestRouter(&databasetest.PersonRepository{})
+	req := validSaveRequest()
+	req.Name = ""
+	req.MiddleName = "Q."
+
+	code, response := serve(t, router, http.MethodPost, "/save", req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+	assert.Equal(t, []models.FieldError{{Field: "name", Message: "is required unless first_name or last_name is given"}}, response.Details)
+}
+
+func TestSavePersonRejectsNilExternalID(t *testing.T) {
+	router := newTestRouter(&databasetest.PersonRepository{})
+	req := validSaveRequest()
+	req.ExternalID = uuid.Nil
+
+	code, response := serve(t, router, http.MethodPost, "/save", req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+	require.Len(t, response.Details, 1)
+	assert.Equal(t, models.FieldError{Field: "external_id", Message: "is required and must not be the nil UUID"}, response.Details[0])
+}
+
+func TestSavePersonConflicts(t *testing.T) {
+	for index, message := range map[string]string{
+		models.ExternalIDIndex: "Person with this external_id already exists",
+		models.EmailIndex:      "Person with this email already exists",
+	} {
+		router := newTestRouter(&databasetest.PersonRepository{
+			CreateFunc: func(ctx context.Context, person *models.Person) error {
+				return &database.UniqueViolationError{Constraint: index, Err: errors.New("duplicate key")}
+			},
+		})
+
+		code, response := serve(t, router, http.MethodPost, "/save", validSaveRequest())
+
+		assert.Equal(t, http.StatusConflict, code, index)
+		assert.Equal(t, message, response.Error)
+	}
+}
+
+func TestSavePersonDatabaseError(t *testing.T) {
+	router := newTestRouter(&databasetest.PersonRepository{
+		CreateFunc: func(ctx context.Context, person *models.Person) error {
+			return errDatabaseDown
+		},
+	})
+
+	code, response := serve(t, router, http.MethodPost, "/save", validSaveRequest())
+
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.Equal(t, "Failed to save person", response.Error)
+}
+
+func TestGetPersonNotFound(t *testing.T) {
+	router := newTestRouter(&databasetest.PersonRepository{
+		GetByIDFunc: func(ctx context.Context, id uint, opts database.GetOptions) (models.Person, error) {
+			return models.Person{}, database.ErrPersonNotFound
+		},
+		GetByExternalIDFunc: func(ctx context.Context, externalID uuid.UUID, opts database.GetOptions) (models.Person, error) {
+			return models.Person{}, database.ErrPersonNotFound
+		},
+	})
+
+	code, _ := serve(t, router, http.MethodGet, "/42", nil)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, _ = serve(t, router, http.MethodGet, "/"+uuid.NewString(), nil)
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestGetPersonDatabaseErrors(t *testing.T) {
+	for err, want := range map[error]int{
+		errDatabaseDown:          http.StatusInternalServerError,
+		context.DeadlineExceeded: http.StatusServiceUnavailable,
+	} {
+		router := newTestRouter(&databasetest.PersonRepository{
+			GetByIDFunc: func(ctx context.Context, id uint, opts database.GetOptions) (models.Person, error) {
+				return models.Person{}, err
+			},
+		})
+
+		code, _ := serve(t, router, http.MethodGet, "/42", nil)
+		assert.Equal(t, want, code, err.Error())
+	}
+}
+
+func TestUpdatePersonNotFound(t *testing.T) {
+	router := newTestRouter(&databasetest.PersonRepository{
+		GetByIDFunc: func(ctx context.Context, id uint, opts database.GetOptions) (models.Person, error) {
+			assert.True(t, opts.FromPrimary)
+			return models.Person{}, database.ErrPersonNotFound
+		},
+	})
+
+	code, _ := serve(t, router, http.MethodPut, "/42", models.UpdatePersonRequest{
+		Name:        "Jane Doe",
+		Email:       "jane@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	})
+
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestUpdatePersonVersionConflict(t *testing.T) {
+	router := newTestRouter(&databasetest.PersonRepository{
+		GetByIDFunc: func(ctx context.Context, id uint, opts database.GetOptions) (models.Person, error) {
+			return models.Person{ID: id, Name: "Jane Doe"}, nil
+		},
+		UpdateFunc: func(ctx context.Context, person *models.Person, changes map[string]interface{}) error {
+			return database.ErrVersionConflict
+		},
+	})
+
+	code, response := serve(t, router, http.MethodPut, "/42", models.UpdatePersonRequest{
+		Name:        "Jane Roe",
+		Email:       "jane@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	})
+
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Equal(t, "Person was modified concurrently", response.Error)
+}
+
+func TestDeletePersonNotFound(t *testing.T) {
+	router := newTestRouter(&databasetest.PersonRepository{
+		DeleteFunc: func(ctx context.Context, id uint) (models.Person, error) {
+			return models.Person{}, database.ErrPersonNotFound
+		},
+	})
+
+	code, _ := serve(t, router, http.MethodDelete, "/42", nil)
+
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestDeletePersonDatabaseError(t *testing.T) {
+	router := newTestRouter(&databasetest.PersonRepository{
+		DeleteFunc: func(ctx context.Context, id uint) (models.Person, error) {
+			return models.Person{}, errDatabaseDown
+		},
+	})
+
+	code, response := serve(t, router, http.MethodDelete, "/42", nil)
+
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.Equal(t, "Failed to delete person", response.Error)
+}
+
+func TestListPersonsSortsByLastNameWithMissingLast(t *testing.T) {
+	for sort, want := range map[string]string{
+		"last_name":  "NULLIF(last_name, '') asc NULLS LAST, id asc",
+		"-last_name": "NULLIF(last_name, '') desc NULLS LAST, id asc",
+	} {
+		var got database.ListOptions
+		router := newTestRouter(&databasetest.PersonRepository{
+			ListFunc: func(ctx context.Context, opts database.ListOptions) ([]models.Person, int64, error) {
+				got = opts
+				return nil, 0, nil
+			},
+		})
+
+		code, _ := serve(t, router, http.MethodGet, "/persons?sort="+sort, nil)
+
+		assert.Equal(t, http.StatusOK, code, sort)
+		assert.Equal(t, want, got.Order, sort)
+	}
+}
+
+func TestListPersonsUsesConfiguredPageSizes(t *testing.T) {
+	var got database.ListOptions
+	h := NewPersonHandler(nil, WithPageSizes(5, 30), WithRepository(&databasetest.PersonRepository{
+		ListFunc: func(ctx context.Context, opts database.ListOptions) ([]models.Person, int64, error) {
+			got = opts
+			return nil, 0, nil
+		},
+	}))
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/persons", h.ListPersons)
+
+	for query, want := range map[string]int{
+		"":               5,
+		"?page_size=abc": 5,
+		"?page_size=12":  12,
+		"?page_size=31":  30,
+		"?page_size=500": 30,
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/persons"+query, nil))
+
+		require.Equal(t, http.StatusOK, w.Code, query)
+		var response models.PersonListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, want, response.PageSize, query)
+		assert.Equal(t, want, got.Limit, query)
+	}
+}
+
+func TestListPersonsPassesPaging(t *testing.T) {
+	var got database.ListOptions
+	router := newTestRouter(&databasetest.PersonRepository{
+		ListFunc: func(ctx context.Context, opts database.ListOptions) ([]models.Person, int64, error) {
+			got = opts
+			return nil, 0, errDatabaseDown
+		},
+	})
+
+	code, _ := serve(t, router, http.MethodGet, "/persons?page=3&page_size=10&sort=-name&name=jan", nil)
+
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.Equal(t, database.ListOptions{
+		Filter:      database.PersonFilter{Name: "jan"},
+		Order:       "name desc, id asc",
+		Limit:       10,
+		Offset:      20,
+		WindowTotal: true,
+	}, got)
+}
+
+func TestRotateExternalIDAssignsFreshID(t *testing.T) {
+	old := uuid.New()
+	var changes map[string]interface{}
+	h := NewPersonHandler(nil, WithRepository(&databasetest.PersonRepository{
+		GetByIDFunc: func(ctx context.Context, id uint, opts database.GetOptions) (models.Person, error) {
+			assert.True(t, opts.FromPrimary)
+			return models.Person{ID: id, ExternalID: old, Name: "Jane Doe"}, nil
+		},
+		UpdateFunc: func(ctx context.Context, person *models.Person, c map[string]interface{}) error {
+			changes = c
+			person.ExternalID = c["external_id"].(uuid.UUID)
+			return nil
+		},
+	}))
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/persons/:id/rotate-external-id", h.RotateExternalID)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/persons/7/rotate-external-id", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response models.PersonResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.NotEqual(t, old, response.ExternalID)
+	assert.Equal(t, uuid.Version(7), response.ExternalID.Version())
+	assert.Equal(t, map[string]interface{}{"external_id": response.ExternalID}, changes)
+}
+
+func TestAnonymizePersonSkipsAnonymizedPerson(t *testing.T) {
+	anonymizedAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	h := NewPersonHandler(nil, WithRepository(&databasetest.PersonRepository{
+		GetByIDFunc: func(ctx context.Context, id uint, opts database.GetOptions) (models.Person, error) {
+			return models.Person{ID: id, Name: models.RedactedName, AnonymizedAt: &anonymizedAt}, nil
+		},
+	}))
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/persons/:id/anonymize", h.AnonymizePerson)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/persons/7/anonymize", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response models.PersonResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, anonymizedAt, *response.AnonymizedAt)
+}
+
+func TestRotateExternalIDNotFound(t *testing.T) {
+	h := NewPersonHandler(nil, WithRepository(&databasetest.PersonRepository{
+		GetByIDFunc: func(ctx context.Context, id uint, opts database.GetOptions) (models.Person, error) {
+			return models.Person{}, database.ErrPersonNotFound
+		},
+	}))
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/persons/:id/rotate-external-id", h.RotateExternalID)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/persons/7/rotate-external-id", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
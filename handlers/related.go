@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"person-service/database"
+	"person-service/models"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetRelatedPersons lists the other persons whose email is at the same
+// domain as the given person's, e.g. colleagues at one organization.
+//
+// @Summary      List persons sharing an email domain
+// @Tags         persons
+// @Produce      json
+// @Param        id         path      int  true   "Person ID"
+// @Param        page       query     int  false  "Page number"
+// @Param        page_size  query     int  false  "Page size (capped at MAX_PAGE_SIZE, 100 by default)"
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200        {object}  models.PersonListResponse
+// @Failure      400        {object}  models.ErrorResponse
+// @Failure      404        {object}  models.ErrorResponse
+// @Failure      500        {object}  models.ErrorResponse
+// @Failure      503        {object}  models.ErrorResponse
+// @Router       /v1/persons/{id}/related [get]
+func (h *PersonHandler) GetRelatedPersons(c *gin.Context) {
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid ID format"))
+		return
+	}
+
+	person, err := h.persons.GetByID(ctx, uint(id), database.GetOptions{})
+	if err != nil {
+		if errors.Is(err, database.ErrPersonNotFound) {
+			c.JSON(http.StatusNotFound, errorResponse(c, "Person not found"))
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "Database error retrieving person", "person_id", id, "error", err)
+		respondDBError(c, err, "Failed to list related persons")
+		return
+	}
+
+	page := parsePositiveInt(c.Query("page"), defaultPage)
+	pageSize := h.parsePageSize(c, "page_size")
+
+	_, domain, _ := strings.Cut(person.Email, "@")
+	persons, total, err := h.persons.List(ctx, database.ListOptions{
+		Filter: database.PersonFilter{EmailDomain: domain, ExcludeID: person.ID},
+		Order:  "id asc",
+		Limit:  pageSize,
+		Offset: (page - 1) * pageSize,
+	})
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to list related persons", "person_id", id, "error", err)
+		respondDBError(c, err, "Failed to list related persons")
+		return
+	}
+
+	data := make([]models.PersonResponse, 0, len(persons))
+	for _, p := range persons {
+		data = append(data, p.ToResponse())
+	}
+
+	c.JSON(http.StatusOK, models.PersonListResponse{
+		Data:     data,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	})
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"person-service/database"
+	"person-service/database/databasetest"
+	"person-service/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRelatedPersonsFiltersByDomain(t *testing.T) {
+	var got database.ListOptions
+	h := NewPersonHandler(nil, WithRepository(&databasetest.PersonRepository{
+		GetByIDFunc: func(ctx context.Context, id uint, opts database.GetOptions) (models.Person, error) {
+			return models.Person{ID: id, Email: "jane@acme.example"}, nil
+		},
+		ListFunc: func(ctx context.Context, opts database.ListOptions) ([]models.Person, int64, error) {
+			got = opts
+			return nil, 0, nil
+		},
+	}))
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/persons/:id/related", h.GetRelatedPersons)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/persons/7/related", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, database.PersonFilter{EmailDomain: "acme.example", ExcludeID: 7}, got.Filter)
+}
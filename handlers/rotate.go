@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"person-service/database"
+	"person-service/events"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RotateExternalID gives a person a fresh UUIDv7 external ID, e.g. after the
+// old one leaked. The old ID stops resolving at once; the change is audited
+// like any other update.
+//
+// @Summary      Rotate person external ID
+// @Tags         persons
+// @Produce      json
+// @Param        id   path      int  true  "Person ID"
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200  {object}  models.PersonResponse
+// @Failure      400  {object}  models.ErrorResponse
+// @Failure      401  {object}  models.ErrorResponse
+// @Failure      404  {object}  models.ErrorResponse
+// @Failure      409  {object}  models.ErrorResponse
+// @Failure      500  {object}  models.ErrorResponse
+// @Failure      503  {object}  models.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /v1/persons/{id}/rotate-external-id [post]
+func (h *PersonHandler) RotateExternalID(c *gin.Context) {
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid ID format"))
+		return
+	}
+
+	// Read from the primary so the version check sees the latest write.
+	person, err := h.persons.GetByID(ctx, uint(id), database.GetOptions{FromPrimary: true, IncludeInactive: true})
+	if err != nil {
+		if errors.Is(err, database.ErrPersonNotFound) {
+			c.JSON(http.StatusNotFound, errorResponse(c, "Person not found"))
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "Database error retrieving person", "person_id", id, "error", err)
+		respondDBError(c, err, "Failed to retrieve person")
+		return
+	}
+
+	externalID, err := uuid.NewV7()
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to generate external ID", "person_id", id, "error", err)
+		c.JSON(http.StatusInternalServerError, errorResponse(c, "Failed to rotate external ID"))
+		return
+	}
+	previous := person.ExternalID
+
+	err = h.persons.Update(ctx, &person, map[string]interface{}{"external_id": externalID})
+	switch {
+	case errors.Is(err, database.ErrVersionConflict):
+		c.JSON(http.StatusConflict, errorResponse(c, "Person was modified concurrently"))
+		return
+	case err != nil:
+		slog.ErrorContext(c.Request.Context(), "Failed to rotate external ID", "person_id", id, "error", err)
+		respondDBError(c, err, "Failed to rotate external ID")
+		return
+	}
+
+	database.AfterCommit(c.Request.Context(), func() {
+		h.cache.Delete(person.ID)
+		h.notifier.Notify(c.Request.Context(), events.PersonUpdated, person)
+	})
+	slog.InfoContext(c.Request.Context(), "Rotated person external ID", "person_id", person.ID, "previous_external_id", previous, "external_id", person.ExternalID)
+	c.JSON(http.StatusOK, person.ToResponse())
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"person-service/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes mounts the person endpoints; writeMiddleware (e.g.
+// authentication) guards only the endpoints that modify data.
+func RegisterRoutes(routes *gin.RouterGroup, h *PersonHandler, writeMiddleware ...gin.HandlerFunc) {
+	routes.GET("/persons", h.ListPersons)
+	routes.GET("/persons/count", h.CountPersons)
+	routes.GET("/persons/stats", h.GetPersonStats)
+	routes.GET("/persons/search", h.SearchPersons)
+	routes.GET("/persons/schema", h.GetPersonSchema)
+	routes.GET("/persons/export.csv", h.ExportPersonsCSV)
+	routes.GET("/persons/stream", h.StreamPersonChanges)
+	routes.GET("/persons/events", h.StreamCreatedPersons)
+	routes.POST("/persons/lookup", middleware.RequireJSON(), h.LookupPersons)
+	routes.GET("/persons/by-external/:external_id", h.GetPersonByExternalID)
+	routes.GET("/persons/:id/history", h.GetPersonHistory)
+	routes.GET("/persons/:id/related", h.GetRelatedPersons)
+	routes.GET("/persons/:id/vcard", h.GetPersonVCard)
+	routes.GET("/:id", h.GetPerson)
+
+	writes := routes.Group("", writeMiddleware...)
+	// The CSV import is a multipart upload; every other write takes JSON.
+	writes.POST("/persons/import", h.ImportPersonsCSV)
+
+	jsonWrites := writes.Group("", middleware.RequireJSON())
+	jsonWrites.POST("/save", h.SavePerson)
+	jsonWrites.POST("/save/batch", h.SavePersonsBatch)
+	// Writes to existing persons run in one request transaction, so a
+	// failure after a partial write leaves nothing behind and their events
+	// and cache evictions only happen once the transaction committed.
+	txWrites := jsonWrites.Group("", middleware.Transaction(h.db))
+	txWrites.DELETE("/persons", h.DeletePersons)
+	txWrites.POST("/persons/:id/rotate-external-id", h.RotateExternalID)
+	txWrites.POST("/persons/:id/anonymize", h.AnonymizePerson)
+	txWrites.PATCH("/persons/:id/status", h.UpdatePersonStatus)
+	txWrites.PUT("/:id", h.UpdatePerson)
+	txWrites.PATCH("/:id", h.PatchPerson)
+	txWrites.DELETE("/:id", h.DeletePerson)
+}
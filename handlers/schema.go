@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"person-service/models"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema is the subset of JSON Schema that request types are described
+// with.
+type JSONSchema struct {
+	Schema     string                 `json:"$schema,omitempty"`
+	Title      string                 `json:"title,omitempty"`
+	Type       string                 `json:"type,omitempty"`
+	Format     string                 `json:"format,omitempty"`
+	Pattern    string                 `json:"pattern,omitempty"`
+	Items      *JSONSchema            `json:"items,omitempty"`
+	Properties map[string]*JSONSchema `json:"properties,omitempty"`
+	Required   []string               `json:"required,omitempty"`
+	AllOf      []*JSONSchema          `json:"allOf,omitempty"`
+	AnyOf      []*JSONSchema          `json:"anyOf,omitempty"`
+}
+
+// schemaPatterns translates binding rules without a JSON Schema format into
+// patterns. Rules missing here and from schemaFormats, and the checks done
+// in Validate, such as name length or a date of birth in the past, are only
+// enforced by the server.
+var schemaPatterns = map[string]string{
+	"e164":             `^\+[1-9][0-9]{1,14}$`,
+	"iso3166_1_alpha2": `^[A-Z]{2}$`,
+}
+
+var schemaFormats = map[string]string{
+	"email": "email",
+}
+
+var savePersonSchema = func() []byte {
+	schema := requestSchema(reflect.TypeOf(models.SavePersonRequest{}))
+	schema.Schema = jsonSchemaDraft
+	schema.Title = "SavePersonRequest"
+	body, err := json.Marshal(schema)
+	if err != nil {
+		panic(err)
+	}
+	return body
+}()
+
+// GetPersonSchema returns the JSON Schema of the POST /save body, generated
+// from the binding tags the server validates with, so clients can check
+// payloads before sending them.
+//
+// @Summary      Person request schema
+// @Tags         persons
+// @Produce      application/schema+json
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200  {object}  handlers.JSONSchema
+// @Router       /v1/persons/schema [get]
+func (h *PersonHandler) GetPersonSchema(c *gin.Context) {
+	// Not application/json, so JSON_NAMING=camel leaves the property names,
+	// which must match the snake_case request keys, alone.
+	c.Data(http.StatusOK, "application/schema+json", savePersonSchema)
+}
+
+// requestSchema describes t from its json, binding, swaggertype and format
+// tags. Slice elements are described by their own tags, which is what the
+// dive rule validates them with.
+func requestSchema(t reflect.Type) *JSONSchema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == reflect.TypeOf(uuid.UUID{}):
+		return &JSONSchema{Type: "string", Format: "uuid"}
+	case t.Kind() == reflect.Struct:
+		return objectSchema(t)
+	case t.Kind() == reflect.Slice:
+		return &JSONSchema{Type: "array", Items: requestSchema(t.Elem())}
+	case t.Kind() == reflect.String:
+		return &JSONSchema{Type: "string"}
+	case t.Kind() == reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	}
+	return &JSONSchema{}
+}
+
+func objectSchema(t reflect.Type) *JSONSchema {
+	schema := &JSONSchema{Type: "object", Properties: make(map[string]*JSONSchema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := requestSchema(field.Type)
+		if swaggerType := field.Tag.Get("swaggertype"); swaggerType != "" {
+			property = &JSONSchema{Type: swaggerType}
+		}
+		if format := field.Tag.Get("format"); format != "" {
+			property.Format = format
+		}
+
+		for _, rule := range strings.Split(field.Tag.Get("binding"), ",") {
+			rule, param, _ := strings.Cut(rule, "=")
+			switch {
+			case rule == "required":
+				schema.Required = append(schema.Required, name)
+			case rule == "required_without_all":
+				// Either this field or one of the others must be present.
+				alternatives := []*JSONSchema{{Required: []string{name}}}
+				for _, other := range strings.Fields(param) {
+					if otherField, ok := t.FieldByName(other); ok {
+						alternatives = append(alternatives, &JSONSchema{Required: []string{jsonFieldName(otherField)}})
+					}
+				}
+				schema.AllOf = append(schema.AllOf, &JSONSchema{AnyOf: alternatives})
+			case rule == "required_without":
+				// Either this field or all of the others must be present.
+				others := &JSONSchema{}
+				for _, other := range strings.Fields(param) {
+					if otherField, ok := t.FieldByName(other); ok {
+						others.Required = append(others.Required, jsonFieldName(otherField))
+					}
+				}
+				schema.AllOf = append(schema.AllOf, &JSONSchema{AnyOf: []*JSONSchema{{Required: []string{name}}, others}})
+			case schemaFormats[rule] != "":
+				property.Format = schemaFormats[rule]
+			case schemaPatterns[rule] != "":
+				property.Pattern = schemaPatterns[rule]
+			}
+		}
+		schema.Properties[name] = property
+	}
+	return schema
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getPersonSchema(t *testing.T) (*httptest.ResponseRecorder, JSONSchema) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/persons/schema", NewPersonHandler(nil).GetPersonSchema)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/persons/schema", nil))
+
+	var schema JSONSchema
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &schema), w.Body.String())
+	return w, schema
+}
+
+func TestPersonSchemaListsRequiredFields(t *testing.T) {
+	w, schema := getPersonSchema(t)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/schema+json", w.Header().Get("Content-Type"))
+	assert.Equal(t, "object", schema.Type)
+	assert.ElementsMatch(t, []string{"external_id", "date_of_birth"}, schema.Required)
+
+	// name may be replaced by first_name or last_name, email by emails.
+	require.Len(t, schema.AllOf, 2)
+	var alternatives [][]string
+	for _, rule := range schema.AllOf {
+		var required []string
+		for _, alternative := range rule.AnyOf {
+			required = append(required, alternative.Required...)
+		}
+		alternatives = append(alternatives, required)
+	}
+	assert.ElementsMatch(t, [][]string{{"name", "first_name", "last_name"}, {"email", "emails"}}, alternatives)
+}
+
+func TestPersonSchemaDescribesFormats(t *testing.T) {
+	_, schema := getPersonSchema(t)
+
+	assert.Equal(t, &JSONSchema{Type: "string", Format: "email"}, schema.Properties["email"])
+	assert.Equal(t, &JSONSchema{Type: "string", Format: "uuid"}, schema.Properties["external_id"])
+	assert.Equal(t, &JSONSchema{Type: "string", Format: "date"}, schema.Properties["date_of_birth"])
+	assert.Equal(t, `^\+[1-9][0-9]{1,14}$`, schema.Properties["phone"].Pattern)
+
+	emails := schema.Properties["emails"]
+	require.NotNil(t, emails)
+	assert.Equal(t, "array", emails.Type)
+	assert.Equal(t, []string{"email"}, emails.Items.Required)
+	assert.Equal(t, "email", emails.Items.Properties["email"].Format)
+
+	address := schema.Properties["address"]
+	require.NotNil(t, address)
+	assert.Equal(t, "object", address.Type)
+	assert.Equal(t, "^[A-Z]{2}$", address.Properties["country"].Pattern)
+}
+
+// TestPersonSchemaCoversBindingRules fails when SavePersonRequest gains a
+// binding rule the schema does not express yet.
+func TestPersonSchemaCoversBindingRules(t *testing.T) {
+	known := map[string]bool{"required": true, "required_without": true, "required_without_all": true, "omitempty": true, "dive": true}
+	for rule := range schemaFormats {
+		known[rule] = true
+	}
+	for rule := range schemaPatterns {
+		known[rule] = true
+	}
+
+	for _, typ := range []reflect.Type{reflect.TypeOf(models.SavePersonRequest{}), reflect.TypeOf(models.Address{}), reflect.TypeOf(models.EmailAddress{})} {
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			for _, rule := range strings.Split(field.Tag.Get("binding"), ",") {
+				rule, _, _ = strings.Cut(rule, "=")
+				if rule != "" {
+					assert.True(t, known[rule], "%s.%s: binding rule %q missing from the schema", typ.Name(), field.Name, rule)
+				}
+			}
+		}
+	}
+}
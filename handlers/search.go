@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"person-service/database"
+	"person-service/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// searchDocument must match the expression of the idx_people_search GIN
+// index for Postgres to use it. It is built from search_name, so queries are
+// folded with models.SearchName before matching.
+const searchDocument = "to_tsvector('simple', search_name || ' ' || email)"
+
+// SearchPersons runs a full-text search over name and email, best matches
+// first. Queries without any full-text match, e.g. a word fragment, fall
+// back to a case-insensitive substring match.
+//
+// @Summary      Search persons
+// @Tags         persons
+// @Produce      json
+// @Param        q          query     string  true   "Search terms"
+// @Param        page       query     int     false  "Page number"
+// @Param        page_size  query     int     false  "Page size (capped at MAX_PAGE_SIZE, 100 by default)"
+// @Param        include_inactive  query  bool  false  "Include deactivated persons"
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200        {object}  models.PersonListResponse
+// @Failure      400        {object}  models.ErrorResponse
+// @Failure      500        {object}  models.ErrorResponse
+// @Failure      503        {object}  models.ErrorResponse
+// @Router       /v1/persons/search [get]
+func (h *PersonHandler) SearchPersons(c *gin.Context) {
+	db, cancel := h.queryDB(c)
+	defer cancel()
+
+	q := models.SearchName(c.Query("q"))
+	if q == "" {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid query: q is required"))
+		return
+	}
+
+	page := parsePositiveInt(c.Query("page"), defaultPage)
+	pageSize := h.parsePageSize(c, "page_size")
+
+	visible := database.PersonFilter{IncludeInactive: includeInactive(c)}.Scope
+	match := func(tx *gorm.DB) *gorm.DB {
+		return tx.Where(searchDocument+" @@ plainto_tsquery('simple', ?)", q)
+	}
+	order := clause.OrderBy{Expression: clause.Expr{
+		SQL:  "ts_rank(" + searchDocument + ", plainto_tsquery('simple', ?)) DESC, id",
+		Vars: []interface{}{q},
+	}}
+
+	var total int64
+	if err := db.Model(&models.Person{}).Scopes(visible, match).Count(&total).Error; err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to search persons", "error", err)
+		respondDBError(c, err, "Failed to search persons")
+		return
+	}
+	if total == 0 {
+		pattern := "%" + database.EscapeLike(q) + "%"
+		match = func(tx *gorm.DB) *gorm.DB {
+			return tx.Where("search_name LIKE ? OR email ILIKE ?", pattern, pattern)
+		}
+		order = clause.OrderBy{Columns: []clause.OrderByColumn{{Column: clause.Column{Name: "id"}}}}
+		if err := db.Model(&models.Person{}).Scopes(visible, match).Count(&total).Error; err != nil {
+			slog.ErrorContext(c.Request.Context(), "Failed to search persons", "error", err)
+			respondDBError(c, err, "Failed to search persons")
+			return
+		}
+	}
+
+	var persons []models.Person
+	if err := db.Scopes(visible, match, database.PreloadEmails).Clauses(order).Limit(pageSize).Offset((page - 1) * pageSize).Find(&persons).Error; err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to search persons", "error", err)
+		respondDBError(c, err, "Failed to search persons")
+		return
+	}
+
+	data := make([]models.PersonResponse, 0, len(persons))
+	for _, person := range persons {
+		data = append(data, person.ToResponse())
+	}
+
+	c.JSON(http.StatusOK, models.PersonListResponse{
+		Data:     data,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	})
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"person-service/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// personSummary is scanned from the single aggregate query behind
+// GetPersonStats.
+type personSummary struct {
+	Total             int64    `gorm:"column:total"`
+	AverageAge        *float64 `gorm:"column:average_age"`
+	CreatedLast7Days  int64    `gorm:"column:created_last_7_days"`
+	CreatedLast30Days int64    `gorm:"column:created_last_30_days"`
+}
+
+// GetPersonStats aggregates in the database; no person rows are loaded.
+//
+// @Summary      Person statistics
+// @Description  Total count, count by decade of birth, average age and recent creations.
+// @Tags         persons
+// @Produce      json
+// @Param        X-Tenant-ID  header    string  true  "Tenant ID"  format(uuid)
+// @Success      200          {object}  models.PersonStatsResponse
+// @Failure      400          {object}  models.ErrorResponse
+// @Failure      500          {object}  models.ErrorResponse
+// @Failure      503          {object}  models.ErrorResponse
+// @Router       /v1/persons/stats [get]
+func (h *PersonHandler) GetPersonStats(c *gin.Context) {
+	db, cancel := h.queryDB(c)
+	defer cancel()
+
+	var summary personSummary
+	err := db.Model(&models.Person{}).Select(`COUNT(*) AS total,
+		AVG(EXTRACT(YEAR FROM age(current_date, date_of_birth))) AS average_age,
+		COUNT(*) FILTER (WHERE created_at >= now() - interval '7 days') AS created_last_7_days,
+		COUNT(*) FILTER (WHERE created_at >= now() - interval '30 days') AS created_last_30_days`).
+		Scan(&summary).Error
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to aggregate persons", "error", err)
+		respondDBError(c, err, "Failed to compute person statistics")
+		return
+	}
+
+	byDecade := make([]models.DecadeCount, 0)
+	err = db.Model(&models.Person{}).
+		Select("EXTRACT(YEAR FROM date_of_birth)::int / 10 * 10 AS decade, COUNT(*) AS count").
+		Group("decade").
+		Order("decade").
+		Scan(&byDecade).Error
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to aggregate persons by decade", "error", err)
+		respondDBError(c, err, "Failed to compute person statistics")
+		return
+	}
+
+	if summary.AverageAge != nil {
+		rounded := math.Round(*summary.AverageAge*10) / 10
+		summary.AverageAge = &rounded
+	}
+
+	c.JSON(http.StatusOK, models.PersonStatsResponse{
+		Total:             summary.Total,
+		ByDecade:          byDecade,
+		AverageAge:        summary.AverageAge,
+		CreatedLast7Days:  summary.CreatedLast7Days,
+		CreatedLast30Days: summary.CreatedLast30Days,
+	})
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"person-service/database"
+	"person-service/events"
+	"person-service/models"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UpdatePersonStatus deactivates or reactivates a person. Deactivated
+// persons are kept but hidden from reads unless they ask for
+// include_inactive=true. Setting the status a person already has changes
+// nothing.
+//
+// @Summary      Activate or deactivate person
+// @Tags         persons
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                         true  "Person ID"
+// @Param        status  body      models.UpdateStatusRequest  true  "New status"
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200     {object}  models.PersonResponse
+// @Failure      400     {object}  models.ErrorResponse
+// @Failure      401     {object}  models.ErrorResponse
+// @Failure      404     {object}  models.ErrorResponse
+// @Failure      409     {object}  models.ErrorResponse
+// @Failure      415     {object}  models.ErrorResponse
+// @Failure      422     {object}  models.ErrorResponse
+// @Failure      500     {object}  models.ErrorResponse
+// @Failure      503     {object}  models.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /v1/persons/{id}/status [patch]
+func (h *PersonHandler) UpdatePersonStatus(c *gin.Context) {
+	ctx, cancel := h.queryContext(c)
+	defer cancel()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(c, "Invalid ID format"))
+		return
+	}
+
+	var req models.UpdateStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	person, err := h.persons.GetByID(ctx, uint(id), database.GetOptions{FromPrimary: true, IncludeInactive: true})
+	if err != nil {
+		if errors.Is(err, database.ErrPersonNotFound) {
+			c.JSON(http.StatusNotFound, errorResponse(c, "Person not found"))
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "Database error retrieving person", "person_id", id, "error", err)
+		respondDBError(c, err, "Failed to retrieve person")
+		return
+	}
+
+	if person.Active == *req.Active {
+		c.JSON(http.StatusOK, person.ToResponse())
+		return
+	}
+
+	err = h.persons.Update(ctx, &person, map[string]interface{}{"active": *req.Active})
+	switch {
+	case errors.Is(err, database.ErrVersionConflict):
+		c.JSON(http.StatusConflict, errorResponse(c, "Person was modified concurrently"))
+		return
+	case err != nil:
+		slog.ErrorContext(c.Request.Context(), "Failed to update person status", "person_id", id, "error", err)
+		respondDBError(c, err, "Failed to update person status")
+		return
+	}
+
+	database.AfterCommit(c.Request.Context(), func() {
+		h.cache.Delete(person.ID)
+		h.notifier.Notify(c.Request.Context(), events.PersonUpdated, person)
+	})
+	slog.InfoContext(c.Request.Context(), "Updated person status", "person_id", person.ID, "active", person.Active)
+	c.JSON(http.StatusOK, person.ToResponse())
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"person-service/database"
+	"person-service/events"
+	"person-service/middleware"
+	"person-service/models"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// sseHeartbeatInterval is how often an idle stream sends a comment, so
+// proxies do not time the connection out.
+var sseHeartbeatInterval = 15 * time.Second
+
+// StreamPersonChanges streams the tenant's person changes as Server-Sent
+// Events named after the change, e.g. "person.created", until the client
+// disconnects. A client too slow to keep up is disconnected.
+//
+// @Summary      Stream person changes
+// @Tags         persons
+// @Produce      text/event-stream
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200  {object}  events.PersonChange  "One event per change"
+// @Router       /v1/persons/stream [get]
+func (h *PersonHandler) StreamPersonChanges(c *gin.Context) {
+	changes, unsubscribe := h.changes.Subscribe()
+	defer unsubscribe()
+	tenant, scoped := database.TenantFromContext(c.Request.Context())
+
+	streamSSE(c, changes, func(change events.PersonChange) {
+		if !scoped || change.TenantID == tenant {
+			c.SSEvent(change.Event, change)
+		}
+	})
+}
+
+// StreamCreatedPersons streams the persons the tenant creates through this
+// instance as "person.created" Server-Sent Events until the client
+// disconnects. Unlike StreamPersonChanges it needs no database connection,
+// but it misses persons created by other instances. A client too slow to
+// keep up is disconnected.
+//
+// @Summary      Stream created persons
+// @Tags         persons
+// @Produce      text/event-stream
+// @Param        X-Tenant-ID  header  string  true  "Tenant ID"  format(uuid)
+// @Success      200  {object}  models.PersonResponse  "One event per created person"
+// @Router       /v1/persons/events [get]
+func (h *PersonHandler) StreamCreatedPersons(c *gin.Context) {
+	created, unsubscribe := h.created.Subscribe()
+	defer unsubscribe()
+
+	streamSSE(c, created, func(person models.Person) {
+		if ownedByRequestTenant(c, person) {
+			c.SSEvent(events.PersonCreated, person.ToResponse())
+		}
+	})
+}
+
+// streamSSE sends the response headers straight away, then passes every
+// value received from ch to send until the client disconnects or ch is
+// closed, writing a heartbeat comment whenever the stream has been idle for
+// sseHeartbeatInterval.
+func streamSSE[T any](c *gin.Context, ch <-chan T, send func(T)) {
+	// The stream no longer needs the database, so it must not hold a
+	// concurrency slot for as long as the client stays connected.
+	middleware.ReleaseConcurrencySlot(c)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case value, ok := <-ch:
+			if !ok {
+				return false
+			}
+			send(value)
+			heartbeat.Reset(sseHeartbeatInterval)
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/database/databasetest"
+	"person-service/events"
+	"person-service/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStreamServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	created := events.NewBroker[models.Person](8)
+	h := NewPersonHandler(nil,
+		WithRepository(&databasetest.PersonRepository{
+			CreateFunc: func(ctx context.Context, person *models.Person) error {
+				person.ID = 1
+				return nil
+			},
+		}),
+		WithPublisher(events.BrokerPublisher{Broker: created}),
+		WithCreatedFeed(created),
+	)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/persons/events", h.StreamCreatedPersons)
+	router.POST("/save", h.SavePerson)
+
+	server := httptest.NewServer(router)
+	// Ending the subscriptions lets Close return while streams are open.
+	t.Cleanup(func() {
+		created.Close()
+		server.Close()
+	})
+	return server
+}
+
+// readStream returns the lines of an event stream opened on server.
+func readStream(t *testing.T, server *httptest.Server) <-chan string {
+	t.Helper()
+
+	resp, err := server.Client().Get(server.URL + "/persons/events")
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	lines := make(chan string, 16)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+	return lines
+}
+
+func TestStreamCreatedPersonsReceivesCreate(t *testing.T) {
+	server := newStreamServer(t)
+	lines := readStream(t, server)
+
+	saveReq := validSaveRequest()
+	jsonBody, err := json.Marshal(saveReq)
+	require.NoError(t, err)
+	resp, err := server.Client().Post(server.URL+"/save", "application/json", bytes.NewReader(jsonBody))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	timeout := time.After(5 * time.Second)
+	var event string
+	for {
+		select {
+		case line := <-lines:
+			if name, ok := strings.CutPrefix(line, "event:"); ok {
+				event = name
+				continue
+			}
+			data, ok := strings.CutPrefix(line, "data:")
+			if !ok {
+				continue
+			}
+			var person models.PersonResponse
+			require.NoError(t, json.Unmarshal([]byte(data), &person))
+			assert.Equal(t, events.PersonCreated, event)
+			assert.Equal(t, saveReq.ExternalID, person.ExternalID)
+			return
+		case <-timeout:
+			t.Fatal("no event received")
+		}
+	}
+}
+
+func TestStreamSendsHeartbeat(t *testing.T) {
+	interval := sseHeartbeatInterval
+	sseHeartbeatInterval = 10 * time.Millisecond
+	t.Cleanup(func() { sseHeartbeatInterval = interval })
+
+	lines := readStream(t, newStreamServer(t))
+
+	select {
+	case line := <-lines:
+		assert.Equal(t, ": heartbeat", line)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no heartbeat received")
+	}
+}
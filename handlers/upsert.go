@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"person-service/database"
+	"person-service/events"
+	"person-service/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// upsertConflict turns an insert that hits a live person with the same
+// external_id into an update of that person's fields, bumping its version.
+// The id, created_at and idempotency key keep their stored values.
+var upsertConflict = clause.OnConflict{
+	Columns:     []clause.Column{{Name: "tenant_id"}, {Name: "external_id"}},
+	TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "deleted_at IS NULL"}}},
+	DoUpdates: append(clause.AssignmentColumns([]string{
+		"name", "first_name", "middle_name", "last_name", "search_name", "email", "phone",
+		"address_street", "address_city", "address_region", "address_postal_code", "address_country",
+		"date_of_birth", "updated_at",
+	}), clause.Assignment{Column: clause.Column{Name: "version"}, Value: gorm.Expr(`"people"."version" + 1`)}),
+}
+
+// upsertPerson creates person or, if its external_id is taken, overwrites
+// the existing person in one atomic statement, replacing its addresses
+// besides the primary email too. It responds 201 for a new person and 200
+// for an updated one.
+func (h *PersonHandler) upsertPerson(c *gin.Context, db *gorm.DB, person models.Person) {
+	err := database.Transaction(db, func(tx *gorm.DB) error {
+		// Lock the current row, if any, so the audit entry gets an accurate
+		// before snapshot.
+		var before *models.Person
+		var existing models.Person
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("external_id = ?", person.ExternalID).First(&existing).Error
+		switch {
+		case err == nil:
+			before = &existing
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		if h.uniqueEmail {
+			if err := database.CheckEmailsFree(tx, person.EmailAddresses(), existing.ID); err != nil {
+				return err
+			}
+		}
+		// Saving the addresses along with the person would skip the ones
+		// already stored and keep those no longer listed, so they are
+		// replaced separately.
+		emails := person.Emails
+		if err := tx.Clauses(upsertConflict).Omit(clause.Associations).Create(&person).Error; err != nil {
+			return err
+		}
+		if err := database.ReplaceEmails(tx, person.ID, emails); err != nil {
+			return err
+		}
+		// Only the id is returned, so reload the row as stored.
+		if err := tx.Scopes(database.PreloadEmails).First(&person, person.ID).Error; err != nil {
+			return err
+		}
+
+		if person.Version == 0 {
+			return database.RecordAudit(tx, models.AuditCreate, nil, &person)
+		}
+		return database.RecordAudit(tx, models.AuditUpdate, before, &person)
+	})
+	if isUniqueViolation(err, models.EmailIndex) {
+		c.JSON(http.StatusConflict, errorResponse(c, "Person with this email already exists"))
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Failed to upsert person", "external_id", person.ExternalID, "error", err)
+		respondDBError(c, err, "Failed to save person")
+		return
+	}
+
+	// New persons start at version 0; the conflict update always bumps it.
+	if person.Version == 0 {
+		slog.InfoContext(c.Request.Context(), "Created person", "person_id", person.ID, "external_id", person.ExternalID)
+		h.publishCreated(c.Request.Context(), person)
+		setLocation(c, person)
+		c.JSON(http.StatusCreated, person.ToResponse())
+		return
+	}
+
+	database.AfterCommit(c.Request.Context(), func() {
+		h.cache.Delete(person.ID)
+		h.notifier.Notify(c.Request.Context(), events.PersonUpdated, person)
+	})
+	slog.InfoContext(c.Request.Context(), "Upserted person", "person_id", person.ID, "external_id", person.ExternalID)
+	c.JSON(http.StatusOK, person.ToResponse())
+}
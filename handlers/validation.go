@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"person-service/middleware"
+	"person-service/models"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(jsonFieldName)
+	}
+}
+
+// jsonFieldName reports validation errors using the JSON field name clients
+// send rather than the Go struct field name.
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// respondBindError answers a failed request decode: 413 when the body
+// exceeded the size limit, 422 with field-level details when the JSON was
+// well-formed but broke a binding rule, and 400 when it could not be parsed
+// into the request type at all.
+func respondBindError(c *gin.Context, err error) {
+	if isBodyTooLarge(err) {
+		c.JSON(http.StatusRequestEntityTooLarge, errorResponse(c, middleware.BodyTooLargeMessage))
+		return
+	}
+	c.JSON(bindErrorStatus(err), bindingErrorResponse(c, err))
+}
+
+func bindErrorStatus(err error) int {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
+
+// respondValidationError answers 422 for a request that decoded fine but
+// broke a business rule, such as a date of birth in the future.
+func respondValidationError(c *gin.Context, err error) {
+	c.JSON(http.StatusUnprocessableEntity, errorResponse(c, "Validation error: "+err.Error()))
+}
+
+func isBodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
+
+func bindingErrorResponse(c *gin.Context, err error) models.ErrorResponse {
+	resp := errorResponse(c, "Invalid request: "+err.Error())
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		for _, fe := range validationErrors {
+			resp.Details = append(resp.Details, models.FieldError{
+				Field:   fieldPath(fe),
+				Message: fieldErrorMessage(fe),
+			})
+		}
+	}
+
+	return resp
+}
+
+// fieldPath returns the dotted JSON path of the failing field, e.g.
+// "address.country", without the request struct's own name.
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+	return path
+}
+
+func fieldErrorMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		if fe.Type() == reflect.TypeOf(uuid.UUID{}) {
+			// The zero value is the only one that fails, whether omitted or
+			// sent explicitly.
+			return "is required and must not be the nil UUID"
+		}
+		return "is required"
+	case "required_without_all":
+		return "is required unless first_name or last_name is given"
+	case "required_without":
+		return "is required unless emails is given"
+	case "email":
+		return "must be a valid email address"
+	case "iso3166_1_alpha2":
+		return "must be an ISO 3166-1 alpha-2 country code, e.g. DE"
+	case "e164":
+		return "must be an E.164 phone number, e.g. +14155552671"
+	default:
+		return fmt.Sprintf("failed on the '%s' validation", fe.Tag())
+	}
+}
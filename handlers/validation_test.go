@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/database/databasetest"
+	"person-service/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavePersonMalformedJSONIsBadRequest(t *testing.T) {
+	router := newTestRouter(&databasetest.PersonRepository{})
+
+	for name, body := range map[string]string{
+		"unparseable":      `{"name": "Jane Doe",`,
+		"type mismatch":    `{"external_id": "550e8400-e29b-41d4-a716-446655440000", "name": 42, "email": "jane@example.com", "date_of_birth": "1990-01-01"}`,
+		"malformed uuid":   `{"external_id": "not-a-uuid", "name": "Jane Doe", "email": "jane@example.com", "date_of_birth": "1990-01-01"}`,
+		"malformed date":   `{"external_id": "550e8400-e29b-41d4-a716-446655440000", "name": "Jane Doe", "email": "jane@example.com", "date_of_birth": "01/01/1990"}`,
+		"array not object": `[]`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/save", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+		var response models.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response), name)
+		assert.Contains(t, response.Error, "Invalid request", name)
+	}
+}
+
+func TestSavePersonInvalidDataIsUnprocessable(t *testing.T) {
+	router := newTestRouter(&databasetest.PersonRepository{})
+
+	missingName := validSaveRequest()
+	missingName.Name = ""
+	invalidEmail := validSaveRequest()
+	invalidEmail.Email = "not-an-email"
+	futureBirth := validSaveRequest()
+	futureBirth.DateOfBirth = models.DateOf(futureBirth.DateOfBirth.Time().AddDate(100, 0, 0))
+
+	for name, req := range map[string]models.SavePersonRequest{
+		"missing name":            missingName,
+		"invalid email":           invalidEmail,
+		"date of birth in future": futureBirth,
+	} {
+		code, response := serve(t, router, http.MethodPost, "/save", req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, code, name)
+		assert.NotEmpty(t, response.Error, name)
+	}
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"person-service/database"
+	"person-service/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const vcardContentType = "text/vcard"
+
+// GetPersonVCard serves /persons/{id}/vcard; the id may be numeric or the
+// external UUID, as for GET /{id}, which also returns a vCard for
+// Accept: text/vcard.
+//
+// @Summary      Download person as vCard
+// @Tags         persons
+// @Produce      text/vcard
+// @Param        id           path      string  true  "Person ID or external ID"
+// @Param        X-Tenant-ID  header    string  true  "Tenant ID"  format(uuid)
+// @Success      200          {string}  string  "vCard 4.0 document"
+// @Failure      400          {object}  models.ErrorResponse
+// @Failure      404          {object}  models.ErrorResponse
+// @Failure      500          {object}  models.ErrorResponse
+// @Failure      503          {object}  models.ErrorResponse
+// @Router       /v1/persons/{id}/vcard [get]
+func (h *PersonHandler) GetPersonVCard(c *gin.Context) {
+	person, ok := h.findPerson(c, c.Param("id"), database.GetOptions{IncludeInactive: includeInactive(c)})
+	if !ok {
+		return
+	}
+	renderVCard(c, person)
+}
+
+// renderVCard writes person as a vCard download named after its external
+// ID.
+func renderVCard(c *gin.Context, person models.Person) {
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.vcf"`, person.ExternalID))
+	c.Data(http.StatusOK, vcardContentType+"; charset=utf-8", []byte(person.VCard()))
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"runtime"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BuildInfo identifies the running build.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+}
+
+type VersionHandler struct {
+	info BuildInfo
+}
+
+// NewVersionHandler serves the given build details along with the Go
+// version the binary was built with.
+func NewVersionHandler(version, commit, buildTime string) *VersionHandler {
+	return &VersionHandler{info: BuildInfo{
+		Version:   version,
+		Commit:    commit,
+		BuildTime: buildTime,
+		GoVersion: runtime.Version(),
+	}}
+}
+
+// @Summary      Build information
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  handlers.BuildInfo
+// @Router       /version [get]
+func (h *VersionHandler) Get(c *gin.Context) {
+	c.JSON(http.StatusOK, h.info)
+}
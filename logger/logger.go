@@ -0,0 +1,18 @@
+package logger
+
+import (
+	"io"
+	"log/slog"
+	"os"
+)
+
+// New returns a JSON logger writing to stdout at the given level.
+func New(level slog.Level) *slog.Logger {
+	return NewWithWriter(os.Stdout, level)
+}
+
+func NewWithWriter(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(contextHandler{slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level: level,
+	})})
+}
@@ -0,0 +1,35 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggerIncludesRequestIDFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithWriter(&buf, slog.LevelInfo)
+
+	log.InfoContext(WithRequestID(context.Background(), "req-123"), "hello")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "req-123", entry["request_id"])
+	assert.Equal(t, "hello", entry["msg"])
+}
+
+func TestLoggerIncludesSubjectFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithWriter(&buf, slog.LevelInfo)
+
+	log.InfoContext(WithSubject(context.Background(), "user-42"), "hello")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "user-42", entry["subject"])
+}
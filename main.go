@@ -1,46 +1,390 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"person-service/cache"
+	"person-service/config"
 	"person-service/database"
+	"person-service/docs"
+	"person-service/emailcheck"
+	"person-service/events"
 	"person-service/handlers"
+	"person-service/logger"
+	"person-service/metrics"
+	"person-service/middleware"
+	"person-service/models"
+	"person-service/tracing"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/otel"
 )
 
+const (
+	shutdownTimeout = 10 * time.Second
+
+	// drainRetryAfter is the Retry-After sent to requests arriving after
+	// shutdown began.
+	drainRetryAfter = 5 * time.Second
+
+	// changeSubscriberBuffer is how many events a stream client may fall
+	// behind before it is disconnected.
+	changeSubscriberBuffer = 64
+)
+
+// Build information, set at build time with -ldflags "-X main.version=...
+// -X main.commit=... -X main.buildTime=..."; see the Makefile.
+var (
+	version   = "dev"
+	commit    = "dev"
+	buildTime = "dev"
+)
+
+// @title        Person Service API
+// @version      1.0
+// @description  REST API for managing person data.
+// @BasePath     /
+//
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+//
+// @securityDefinitions.apikey  ApiKeyAuth
+// @in                          header
+// @name                        X-API-Key
 func main() {
-	log.Println("Starting Person Service...")
+	cfg, err := config.Load()
+	// On error cfg is zero-valued, so the logger falls back to info level.
+	slog.SetDefault(logger.New(cfg.LogLevel))
+	if err != nil {
+		slog.Error("Invalid configuration", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("Starting Person Service")
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.OTLPEndpoint)
+	if err != nil {
+		slog.Error("Failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("Failed to flush traces", "error", err)
+		}
+	}()
 
-	db, err := database.Connect()
+	db, err := database.Connect(cfg.Database)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		slog.Error("Failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("Database connected successfully")
+
+	if err := database.RegisterTracing(db, otel.GetTracerProvider()); err != nil {
+		slog.Error("Failed to register database tracing", "error", err)
+		os.Exit(1)
+	}
+
+	if err := database.RegisterTenantScope(db); err != nil {
+		slog.Error("Failed to register tenant scoping", "error", err)
+		os.Exit(1)
 	}
-	log.Println("Database connected successfully")
 
 	if err := database.Migrate(db); err != nil {
-		log.Fatal("Failed to migrate database:", err)
+		slog.Error("Failed to migrate database", "error", err)
+		os.Exit(1)
 	}
-	log.Println("Database migration completed")
+	slog.Info("Database migration completed")
 
-	personHandler := handlers.NewPersonHandler(db)
+	if err := database.CheckEmailUniqueness(context.Background(), db, cfg.UniqueEmail); err != nil {
+		slog.Error("Email uniqueness does not match the database", "unique_email", cfg.UniqueEmail, "error", err)
+		os.Exit(1)
+	}
 
-	router := gin.Default()
+	filled, err := database.BackfillSearchNames(context.Background(), db)
+	if err != nil {
+		slog.Error("Failed to backfill search names", "error", err)
+		os.Exit(1)
+	}
+	if filled > 0 {
+		slog.Info("Backfilled search names", "persons", filled)
+	}
 
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "ok"})
+	// Created persons always feed the in-process event stream, and Kafka
+	// when it is configured.
+	createdPersons := events.NewBroker[models.Person](changeSubscriberBuffer)
+	publisher := events.MultiPublisher{events.BrokerPublisher{Broker: createdPersons}}
+	if len(cfg.KafkaBrokers) > 0 && cfg.KafkaTopic != "" {
+		kafkaPublisher := events.NewKafkaPublisher(cfg.KafkaBrokers, cfg.KafkaTopic)
+		defer func() {
+			if err := kafkaPublisher.Close(); err != nil {
+				slog.Error("Failed to close Kafka publisher", "error", err)
+			}
+		}()
+		publisher = append(publisher, kafkaPublisher)
+		slog.Info("Publishing person events to Kafka", "brokers", cfg.KafkaBrokers, "topic", cfg.KafkaTopic)
+	}
+
+	var notifier events.PersonNotifier = events.NoopNotifier{}
+	if len(cfg.WebhookURLs) > 0 {
+		dispatcher := events.NewWebhookDispatcher(cfg.WebhookURLs, cfg.WebhookSecret)
+		defer dispatcher.Close()
+		notifier = dispatcher
+		slog.Info("Delivering person webhooks", "urls", len(cfg.WebhookURLs))
+	}
+
+	models.SetAgeLimits(models.AgeLimits{Min: cfg.MinAge, Max: cfg.MaxAge})
+	if cfg.RejectDisposableEmail {
+		domains, err := disposableDomains(cfg.DisposableDomainsFile)
+		if err != nil {
+			slog.Error("Failed to load disposable email domains", "error", err)
+			os.Exit(1)
+		}
+		models.SetDisposableDomains(domains)
+		slog.Info("Rejecting disposable email domains", "domains", len(domains))
+	}
+	var emailVerifier emailcheck.Verifier = emailcheck.NoopVerifier{}
+	if cfg.VerifyEmailMX {
+		emailVerifier = emailcheck.NewMXVerifier(net.DefaultResolver)
+		slog.Info("Verifying email domains accept mail")
+	}
+
+	var personCache cache.PersonCache = cache.NoopCache{}
+	if cfg.CacheTTL > 0 {
+		personCache = cache.NewLRU(cfg.CacheSize, cfg.CacheTTL)
+		slog.Info("Caching person lookups", "ttl", cfg.CacheTTL.String(), "size", cfg.CacheSize)
+	}
+
+	personChanges := events.NewBroker[events.PersonChange](changeSubscriberBuffer)
+	listenCtx, stopListening := context.WithCancel(context.Background())
+	defer stopListening()
+	go database.Listen(listenCtx, db, database.PersonChangesChannel, func(payload string) {
+		change, err := events.ParsePersonChange(payload)
+		if err != nil {
+			slog.Error("Malformed person change notification", "payload", payload, "error", err)
+			return
+		}
+		personChanges.Publish(change)
 	})
 
-	router.POST("/save", personHandler.SavePerson)
-	router.GET("/:id", personHandler.GetPerson)
+	// Background jobs are stopped, and waited for, before the database
+	// connections close.
+	var background sync.WaitGroup
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	if retention := cfg.Retention(); retention > 0 {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			database.RunRetention(backgroundCtx, db, retention, cfg.RetentionInterval, func(ids []uint) {
+				for _, id := range ids {
+					personCache.Delete(id)
+				}
+			})
+		}()
+		slog.Info("Purging persons past retention", "days", cfg.RetentionDays, "interval", cfg.RetentionInterval.String())
+	}
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	personHandler := handlers.NewPersonHandler(db,
+		handlers.WithQueryTimeout(cfg.QueryTimeout),
+		handlers.WithPageSizes(cfg.DefaultPageSize, cfg.MaxPageSize),
+		handlers.WithPublisher(publisher),
+		handlers.WithNotifier(notifier),
+		handlers.WithCache(personCache),
+		handlers.WithEmailVerifier(emailVerifier),
+		handlers.WithChangeFeed(personChanges),
+		handlers.WithCreatedFeed(createdPersons),
+		handlers.WithUniqueEmail(cfg.UniqueEmail),
+	)
+	healthHandler := handlers.NewHealthHandler(db)
+	appMetrics := metrics.New(db)
+	if err := appMetrics.InstrumentDB(db); err != nil {
+		slog.Error("Failed to register database metrics", "error", err)
+		os.Exit(1)
+	}
+
+	router, err := newRouter(cfg.TrustedProxies)
+	if err != nil {
+		slog.Error("Invalid trusted proxies", "error", err)
+		os.Exit(1)
+	}
+	router.Use(gin.Logger(), middleware.Recovery())
+	router.Use(middleware.Tracing(otel.GetTracerProvider()))
+	router.Use(middleware.RequestID())
+	router.Use(appMetrics.Middleware())
+	router.Use(middleware.CORS(cfg.AllowedOrigins))
+	router.Use(middleware.BodyLimit(cfg.MaxBodyBytes))
+
+	// Once shutdown begins, readiness fails and person requests get 503;
+	// liveness and metrics keep answering.
+	drainer := middleware.NewDrainer(drainRetryAfter)
+
+	root := router.Group(cfg.BasePath)
+	root.GET("/livez", healthHandler.Live)
+	root.GET("/readyz", drainer.Middleware(), healthHandler.Ready)
+	root.GET("/health", drainer.Middleware(), healthHandler.Ready)
+	root.GET("/metrics", appMetrics.Handler())
+	root.GET("/version", handlers.NewVersionHandler(version, commit, buildTime).Get)
+	registerDocRoutes(root, cfg.BasePath)
+
+	var writeMiddleware []gin.HandlerFunc
+	if auth := authMiddleware(cfg); auth != nil {
+		writeMiddleware = append(writeMiddleware, auth)
+	} else {
+		slog.Warn("Neither JWT_SECRET nor API_KEYS is set; write endpoints are unauthenticated")
+	}
+
+	var personMiddleware []gin.HandlerFunc
+	if cfg.JSONNaming == config.JSONNamingCamel {
+		personMiddleware = append(personMiddleware, middleware.CamelCaseJSON())
+	}
+	personMiddleware = append(personMiddleware, drainer.Middleware(), middleware.Tenant())
+	if cfg.RateLimitRPS > 0 {
+		personMiddleware = append(personMiddleware, middleware.NewRateLimiter(cfg.RateLimitRPS, cfg.RateLimitBurst).Middleware())
 	}
+	if cfg.MaxConcurrentRequests > 0 {
+		limiter := middleware.NewConcurrencyLimiter(cfg.MaxConcurrentRequests)
+		appMetrics.TrackInFlight(limiter.InFlight)
+		personMiddleware = append(personMiddleware, limiter.Middleware())
+		slog.Info("Limiting concurrent requests", "max", cfg.MaxConcurrentRequests)
+	}
+
+	// Unprefixed routes are kept as deprecated aliases of /v1.
+	handlers.RegisterRoutes(root.Group("/v1", personMiddleware...), personHandler, writeMiddleware...)
+	handlers.RegisterRoutes(root.Group("", append(personMiddleware, middleware.Deprecated(cfg.BasePath, "/v1"))...), personHandler, writeMiddleware...)
+
+	server := &http.Server{
+		Addr:    cfg.Addr(),
+		Handler: router,
+	}
+	// Change streams never end on their own; close them so shutdown does
+	// not wait for their clients.
+	server.RegisterOnShutdown(personChanges.Close)
+	server.RegisterOnShutdown(createdPersons.Close)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		slog.Error("Failed to start server", "error", err)
+		os.Exit(1)
+	}
+
+	go func() {
+		slog.Info("Server starting", "addr", server.Addr, "tls", cfg.TLSEnabled())
+		if err := serve(server, listener, cfg); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Failed to start server", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	slog.Info("Shutting down server")
+
+	drainer.Start()
+	if cfg.ShutdownDrainDelay > 0 {
+		slog.Info("Draining before shutdown", "delay", cfg.ShutdownDrainDelay.String())
+		time.Sleep(cfg.ShutdownDrainDelay)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Server forced to shutdown", "error", err)
+	}
+
+	stopBackground()
+	background.Wait()
+
+	if sqlDB, err := db.DB(); err == nil {
+		if err := sqlDB.Close(); err != nil {
+			slog.Error("Failed to close database connection", "error", err)
+		}
+	}
+
+	slog.Info("Server stopped")
+}
+
+// newRouter creates the engine, believing X-Forwarded-For and X-Real-IP
+// only from trustedProxies, so ClientIP (used by rate limiting and request
+// logs) cannot be spoofed by clients connecting directly.
+func newRouter(trustedProxies []string) (*gin.Engine, error) {
+	router := gin.New()
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, err
+	}
+	return router, nil
+}
+
+// serve serves HTTPS when a certificate is configured and plain HTTP
+// otherwise.
+func serve(server *http.Server, listener net.Listener, cfg config.Config) error {
+	if cfg.TLSEnabled() {
+		return server.ServeTLS(listener, cfg.TLSCertFile, cfg.TLSKeyFile)
+	}
+	return server.Serve(listener)
+}
+
+// disposableDomains reads the email domain blocklist from path, or returns
+// the built-in list when path is empty.
+func disposableDomains(path string) ([]string, error) {
+	if path == "" {
+		return models.DefaultDisposableDomains(), nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return models.ParseDomainList(f)
+}
+
+// authMiddleware builds the write-endpoint authentication from the JWT
+// secret and API keys. Either can be enabled on its own; with both, a request
+// may present an API key or a bearer token. It returns nil when neither is
+// configured.
+func authMiddleware(cfg config.Config) gin.HandlerFunc {
+	var jwtAuth, apiKeyAuth gin.HandlerFunc
+	if cfg.JWTSecret != "" {
+		jwtAuth = middleware.JWTAuth([]byte(cfg.JWTSecret))
+	}
+	if len(cfg.APIKeys) > 0 {
+		apiKeyAuth = middleware.APIKeyAuth(cfg.APIKeys)
+	}
+
+	switch {
+	case jwtAuth != nil && apiKeyAuth != nil:
+		return middleware.APIKeyOr(apiKeyAuth, jwtAuth)
+	case jwtAuth != nil:
+		return jwtAuth
+	default:
+		return apiKeyAuth
+	}
+}
 
-	log.Printf("Server starting on port %s", port)
-	if err := router.Run(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+// registerDocRoutes serves the OpenAPI document and Swagger UI, pointing
+// both at the routes under basePath.
+func registerDocRoutes(routes *gin.RouterGroup, basePath string) {
+	docs.SwaggerInfo.BasePath = basePath
+	if basePath == "" {
+		docs.SwaggerInfo.BasePath = "/"
 	}
+	routes.GET("/openapi.json", docs.Handler)
+	routes.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL(basePath+"/openapi.json")))
 }
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"person-service/config"
+	"person-service/database"
+	"person-service/database/databasetest"
+	"person-service/docs"
+	"person-service/handlers"
+	"person-service/middleware"
+	"person-service/models"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSelfSignedCert writes a certificate for 127.0.0.1 and its key to dir.
+func writeSelfSignedCert(t *testing.T, dir string) (certFile, keyFile string, certDER []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "person-service-test"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	certDER, err = x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile, certDER
+}
+
+func TestServeTLSHandshake(t *testing.T) {
+	certFile, keyFile, certDER := writeSelfSignedCert(t, t.TempDir())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	})}
+	defer server.Close()
+
+	cfg := config.Config{TLSCertFile: certFile, TLSKeyFile: keyFile}
+	go serve(server, listener, cfg)
+
+	cert, err := x509.ParseCertificate(certDER)
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+
+	resp, err := client.Get("https://" + listener.Addr().String())
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "ok", string(body))
+	require.NotNil(t, resp.TLS)
+	assert.True(t, resp.TLS.HandshakeComplete)
+}
+
+func TestDisposableDomainsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "domains.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# custom list\nexample-trash.com\n"), 0o600))
+
+	domains, err := disposableDomains(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example-trash.com"}, domains)
+}
+
+func TestDisposableDomainsDefaultsToBuiltInList(t *testing.T) {
+	domains, err := disposableDomains("")
+
+	require.NoError(t, err)
+	assert.Contains(t, domains, "mailinator.com")
+}
+
+func TestRoutesUnderBasePath(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Cleanup(func() { docs.SwaggerInfo.BasePath = "/" })
+
+	const basePath = "/person-service"
+	h := handlers.NewPersonHandler(nil, handlers.WithRepository(&databasetest.PersonRepository{
+		GetByIDFunc: func(ctx context.Context, id uint, opts database.GetOptions) (models.Person, error) {
+			return models.Person{ID: id, Name: "Jane Doe"}, nil
+		},
+	}))
+	router := gin.New()
+	root := router.Group(basePath)
+	registerDocRoutes(root, basePath)
+	handlers.RegisterRoutes(root.Group("/v1"), h)
+	handlers.RegisterRoutes(root.Group("", middleware.Deprecated(basePath, "/v1")), h)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, get("/person-service/v1/42").Code)
+	assert.Equal(t, http.StatusNotFound, get("/v1/42").Code)
+	assert.Equal(t, http.StatusNotFound, get("/openapi.json").Code)
+
+	legacy := get("/person-service/42")
+	assert.Equal(t, http.StatusOK, legacy.Code)
+	assert.Equal(t, `</person-service/v1/42>; rel="successor-version"`, legacy.Header().Get("Link"))
+
+	spec := get("/person-service/openapi.json")
+	require.Equal(t, http.StatusOK, spec.Code)
+	var doc struct {
+		BasePath string `json:"basePath"`
+	}
+	require.NoError(t, json.Unmarshal(spec.Body.Bytes(), &doc))
+	assert.Equal(t, basePath, doc.BasePath)
+}
+
+func TestClientIPHonoursOnlyTrustedProxies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router, err := newRouter([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	router.GET("/ip", func(c *gin.Context) {
+		c.String(http.StatusOK, c.ClientIP())
+	})
+
+	clientIP := func(remoteAddr string) string {
+		req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", "203.0.113.7")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Body.String()
+	}
+
+	assert.Equal(t, "203.0.113.7", clientIP("10.1.2.3:41000"))
+	assert.Equal(t, "198.51.100.9", clientIP("198.51.100.9:41000"))
+}
+
+func TestNewRouterRejectsMalformedProxy(t *testing.T) {
+	_, err := newRouter([]string{"proxy.internal"})
+
+	assert.Error(t, err)
+}
+
+func TestVersionDefaultsToDev(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/version", handlers.NewVersionHandler(version, commit, buildTime).Get)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var info map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+	assert.Equal(t, map[string]string{
+		"version":    "dev",
+		"commit":     "dev",
+		"build_time": "dev",
+		"go_version": runtime.Version(),
+	}, info)
+}
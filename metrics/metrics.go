@@ -0,0 +1,171 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"person-service/models"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
+)
+
+const (
+	namespace          = "person_service"
+	personCountTimeout = 2 * time.Second
+
+	queryStartKey = "metrics:query_start"
+)
+
+type Metrics struct {
+	registry        *prometheus.Registry
+	requestsTotal   *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	dbQueriesTotal  *prometheus.CounterVec
+	dbQueryDuration *prometheus.HistogramVec
+}
+
+// New creates a metrics registry with HTTP request metrics and a persons
+// gauge that is counted from the database on every scrape.
+func New(db *gorm.DB) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Total number of HTTP requests by route, method and status code.",
+		}, []string{"route", "method", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route, method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		dbQueriesTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "db_queries_total",
+			Help:      "Total number of database queries by operation and outcome.",
+		}, []string{"operation", "status"}),
+		dbQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Database query latency by operation.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+	}
+
+	personsTotal := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "persons_total",
+		Help:      "Current number of persons stored.",
+	}, func() float64 {
+		return countPersons(db)
+	})
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requestsTotal,
+		m.requestDuration,
+		m.dbQueriesTotal,
+		m.dbQueryDuration,
+		personsTotal,
+	)
+
+	return m
+}
+
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		m.requestsTotal.WithLabelValues(route, c.Request.Method, status).Inc()
+		m.requestDuration.WithLabelValues(route, c.Request.Method, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+// InstrumentDB records the count and latency of every GORM operation on db.
+func (m *Metrics) InstrumentDB(db *gorm.DB) error {
+	before := func(tx *gorm.DB) {
+		tx.InstanceSet(queryStartKey, time.Now())
+	}
+	after := func(operation string) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			value, ok := tx.InstanceGet(queryStartKey)
+			if !ok {
+				return
+			}
+			status := "ok"
+			if tx.Error != nil && !errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+				status = "error"
+			}
+			m.dbQueriesTotal.WithLabelValues(operation, status).Inc()
+			m.dbQueryDuration.WithLabelValues(operation).Observe(time.Since(value.(time.Time)).Seconds())
+		}
+	}
+
+	callbacks := db.Callback()
+	registrations := []struct {
+		operation string
+		before    func(string, func(*gorm.DB)) error
+		after     func(string, func(*gorm.DB)) error
+	}{
+		{"create", callbacks.Create().Before("gorm:create").Register, callbacks.Create().After("gorm:create").Register},
+		{"query", callbacks.Query().Before("gorm:query").Register, callbacks.Query().After("gorm:query").Register},
+		{"update", callbacks.Update().Before("gorm:update").Register, callbacks.Update().After("gorm:update").Register},
+		{"delete", callbacks.Delete().Before("gorm:delete").Register, callbacks.Delete().After("gorm:delete").Register},
+		{"row", callbacks.Row().Before("gorm:row").Register, callbacks.Row().After("gorm:row").Register},
+		{"raw", callbacks.Raw().Before("gorm:raw").Register, callbacks.Raw().After("gorm:raw").Register},
+	}
+	for _, r := range registrations {
+		if err := r.before("metrics:before_"+r.operation, before); err != nil {
+			return err
+		}
+		if err := r.after("metrics:after_"+r.operation, after(r.operation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TrackInFlight exports inFlight, the number of requests currently being
+// handled, as a gauge read on every scrape.
+func (m *Metrics) TrackInFlight(inFlight func() int) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "Requests currently holding a concurrency slot.",
+	}, func() float64 {
+		return float64(inFlight())
+	}))
+}
+
+func (m *Metrics) Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+}
+
+func countPersons(db *gorm.DB) float64 {
+	ctx, cancel := context.WithTimeout(context.Background(), personCountTimeout)
+	defer cancel()
+
+	var count int64
+	if err := db.WithContext(ctx).Model(&models.Person{}).Count(&count).Error; err != nil {
+		slog.Error("Failed to count persons for metrics", "error", err)
+		return math.NaN()
+	}
+	return float64(count)
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func postWithAPIKey(router *gin.Engine, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/save", nil)
+	if key != "" {
+		req.Header.Set(APIKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAPIKeyAuthValidKey(t *testing.T) {
+	router := newAuthRouter(APIKeyAuth([]string{"key-one", "key-two"}))
+
+	w := postWithAPIKey(router, "key-two")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "api-key:")
+	assert.NotContains(t, w.Body.String(), "key-two")
+}
+
+func TestAPIKeyAuthInvalidKey(t *testing.T) {
+	router := newAuthRouter(APIKeyAuth([]string{"key-one"}))
+
+	assertUnauthorized(t, postWithAPIKey(router, "key-three"))
+}
+
+func TestAPIKeyAuthMissingHeader(t *testing.T) {
+	router := newAuthRouter(APIKeyAuth([]string{"key-one"}))
+
+	assertUnauthorized(t, postWithAPIKey(router, ""))
+}
+
+func TestAPIKeyOrFallsBackToJWT(t *testing.T) {
+	router := newAuthRouter(APIKeyOr(APIKeyAuth([]string{"key-one"}), JWTAuth(testSecret)))
+
+	assert.Equal(t, http.StatusOK, postWithAPIKey(router, "key-one").Code)
+	assertUnauthorized(t, postWithAPIKey(router, "key-three"))
+
+	token := signToken(t, testSecret, jwt.MapClaims{"sub": "user-42"})
+	w := postWithAuthorization(router, "Bearer "+token)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "user-42", w.Body.String())
+}
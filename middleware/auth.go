@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"person-service/logger"
+	"person-service/models"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const SubjectKey = "subject"
+
+// JWTAuth requires an HMAC-signed bearer token in the Authorization header.
+// The token's subject is stored under SubjectKey and in the request context
+// for logging.
+func JWTAuth(secret []byte) gin.HandlerFunc {
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}))
+	keyFunc := func(*jwt.Token) (any, error) { return secret, nil }
+
+	return func(c *gin.Context) {
+		tokenString, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || tokenString == "" {
+			abortUnauthorized(c, "Missing bearer token")
+			return
+		}
+
+		token, err := parser.Parse(tokenString, keyFunc)
+		if err != nil {
+			abortUnauthorized(c, "Invalid or expired token")
+			return
+		}
+
+		subject, _ := token.Claims.GetSubject()
+		c.Set(SubjectKey, subject)
+		c.Request = c.Request.WithContext(logger.WithSubject(c.Request.Context(), subject))
+
+		c.Next()
+	}
+}
+
+func abortUnauthorized(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", "Bearer")
+	c.AbortWithStatusJSON(http.StatusUnauthorized, models.ErrorResponse{
+		Error:     message,
+		RequestID: c.GetString(RequestIDKey),
+	})
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecret = []byte("test-secret")
+
+func newAuthRouter(auth gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID())
+	router.POST("/save", auth, func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(SubjectKey))
+	})
+	return router
+}
+
+func signToken(t *testing.T, secret []byte, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	require.NoError(t, err)
+	return token
+}
+
+func postWithAuthorization(router *gin.Engine, authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/save", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func assertUnauthorized(t *testing.T, w *httptest.ResponseRecorder) {
+	t.Helper()
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	var resp models.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.NotEmpty(t, resp.Error)
+	assert.Equal(t, w.Header().Get(RequestIDHeader), resp.RequestID)
+}
+
+func TestJWTAuthValidToken(t *testing.T) {
+	router := newAuthRouter(JWTAuth(testSecret))
+	token := signToken(t, testSecret, jwt.MapClaims{
+		"sub": "user-42",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	w := postWithAuthorization(router, "Bearer "+token)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "user-42", w.Body.String())
+}
+
+func TestJWTAuthExpiredToken(t *testing.T) {
+	router := newAuthRouter(JWTAuth(testSecret))
+	token := signToken(t, testSecret, jwt.MapClaims{
+		"sub": "user-42",
+		"exp": time.Now().Add(-time.Minute).Unix(),
+	})
+
+	assertUnauthorized(t, postWithAuthorization(router, "Bearer "+token))
+}
+
+func TestJWTAuthWrongSecret(t *testing.T) {
+	router := newAuthRouter(JWTAuth(testSecret))
+	token := signToken(t, []byte("other-secret"), jwt.MapClaims{"sub": "user-42"})
+
+	assertUnauthorized(t, postWithAuthorization(router, "Bearer "+token))
+}
+
+func TestJWTAuthMissingHeader(t *testing.T) {
+	router := newAuthRouter(JWTAuth(testSecret))
+
+	w := postWithAuthorization(router, "")
+
+	assertUnauthorized(t, w)
+	assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+	"person-service/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodyTooLargeMessage is the error reported with 413 responses.
+const BodyTooLargeMessage = "Request body too large"
+
+// BodyLimit caps request bodies at limit bytes. A declared Content-Length
+// above the limit is rejected with 413 straight away; otherwise the body is
+// wrapped in http.MaxBytesReader so reading past the limit fails with
+// *http.MaxBytesError, which handlers answer with 413 as well.
+func BodyLimit(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > limit {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, models.ErrorResponse{
+				Error:     BodyTooLargeMessage,
+				RequestID: c.GetString(RequestIDKey),
+			})
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
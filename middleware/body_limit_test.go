@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBodyLimitedRouter(limit int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(BodyLimit(limit))
+	router.POST("/save", func(c *gin.Context) {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				c.Status(http.StatusRequestEntityTooLarge)
+				return
+			}
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusCreated)
+	})
+	return router
+}
+
+func TestBodyLimitRejectsDeclaredOversizedBody(t *testing.T) {
+	router := newBodyLimitedRouter(10)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/save", strings.NewReader(strings.Repeat("x", 11))))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	var resp models.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, BodyTooLargeMessage, resp.Error)
+}
+
+func TestBodyLimitCutsOffUndeclaredOversizedBody(t *testing.T) {
+	router := newBodyLimitedRouter(10)
+
+	req := httptest.NewRequest("POST", "/save", strings.NewReader(strings.Repeat("x", 11)))
+	req.ContentLength = -1
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestBodyLimitAllowsBodyWithinLimit(t *testing.T) {
+	router := newBodyLimitedRouter(10)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/save", strings.NewReader(strings.Repeat("x", 10))))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
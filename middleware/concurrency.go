@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"person-service/models"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// ConcurrencyLimitMessage is the error reported with 503 responses when
+	// every slot is taken.
+	ConcurrencyLimitMessage = "Too many concurrent requests"
+
+	concurrencyRetryAfter = time.Second
+	concurrencyReleaseKey = "concurrency:release"
+)
+
+// ConcurrencyLimiter caps how many requests are handled at once, so a burst
+// cannot queue up behind the database pool without bound.
+type ConcurrencyLimiter struct {
+	slots chan struct{}
+}
+
+func NewConcurrencyLimiter(limit int) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{slots: make(chan struct{}, limit)}
+}
+
+// InFlight reports how many requests currently hold a slot.
+func (l *ConcurrencyLimiter) InFlight() int {
+	return len(l.slots)
+}
+
+// Middleware takes a slot for the rest of the request, answering 503 with
+// Retry-After straight away when none is free. The slot is released even if
+// the handler panics.
+func (l *ConcurrencyLimiter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		select {
+		case l.slots <- struct{}{}:
+		default:
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(concurrencyRetryAfter.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, models.ErrorResponse{
+				Error:     ConcurrencyLimitMessage,
+				RequestID: c.GetString(RequestIDKey),
+			})
+			return
+		}
+
+		release := sync.OnceFunc(func() { <-l.slots })
+		defer release()
+		c.Set(concurrencyReleaseKey, release)
+
+		c.Next()
+	}
+}
+
+// ReleaseConcurrencySlot gives the request's slot back early, for handlers
+// such as event streams that stay open long after they stop needing the
+// database. It does nothing when the request holds no slot.
+func ReleaseConcurrencySlot(c *gin.Context) {
+	if release, ok := c.Get(concurrencyReleaseKey); ok {
+		release.(func())()
+	}
+}
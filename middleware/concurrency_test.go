@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBlockingRouter serves /slow until release is closed, signalling on
+// entered once each request holds its slot.
+func newBlockingRouter(l *ConcurrencyLimiter, entered chan<- struct{}, release <-chan struct{}) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(l.Middleware())
+	router.GET("/slow", func(c *gin.Context) {
+		entered <- struct{}{}
+		<-release
+		c.Status(http.StatusOK)
+	})
+	router.GET("/stream", func(c *gin.Context) {
+		ReleaseConcurrencySlot(c)
+		entered <- struct{}{}
+		<-release
+		c.Status(http.StatusOK)
+	})
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+	return router
+}
+
+func get(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+func TestConcurrencyLimiterRejectsExcessRequests(t *testing.T) {
+	l := NewConcurrencyLimiter(2)
+	entered, release := make(chan struct{}), make(chan struct{})
+	router := newBlockingRouter(l, entered, release)
+
+	var wg sync.WaitGroup
+	codes := make([]int, 2)
+	for i := range codes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes[i] = get(router, "/slow").Code
+		}()
+		<-entered
+	}
+	assert.Equal(t, 2, l.InFlight())
+
+	w := get(router, "/slow")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	var resp models.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, ConcurrencyLimitMessage, resp.Error)
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK}, codes)
+	assert.Zero(t, l.InFlight())
+}
+
+func TestConcurrencyLimiterReleasesSlotEarly(t *testing.T) {
+	l := NewConcurrencyLimiter(1)
+	entered, release := make(chan struct{}), make(chan struct{})
+	router := newBlockingRouter(l, entered, release)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		get(router, "/stream")
+	}()
+	<-entered
+
+	assert.Zero(t, l.InFlight())
+	close(release)
+	<-done
+	assert.Zero(t, l.InFlight())
+}
+
+func TestConcurrencyLimiterReleasesSlotOnPanic(t *testing.T) {
+	l := NewConcurrencyLimiter(1)
+	router := newBlockingRouter(l, nil, nil)
+
+	assert.Panics(t, func() { get(router, "/panic") })
+
+	assert.Zero(t, l.InFlight())
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+	"person-service/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UnsupportedMediaTypeMessage is the error reported with 415 responses.
+const UnsupportedMediaTypeMessage = "Content-Type must be application/json"
+
+// RequireJSON rejects requests that carry a body with any Content-Type other
+// than application/json, parameters such as charset=utf-8 aside, with 415.
+// Requests without a body, such as most deletes, pass through.
+func RequireJSON() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength != 0 && !isJSON(c.GetHeader("Content-Type")) {
+			c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, models.ErrorResponse{
+				Error:     UnsupportedMediaTypeMessage,
+				RequestID: c.GetString(RequestIDKey),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newJSONOnlyRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequireJSON())
+	router.POST("/save", func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+	router.DELETE("/:id", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	return router
+}
+
+func TestRequireJSONRejectsFormPost(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/save", strings.NewReader("name=Jane+Doe&email=jane%40example.com"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	newJSONOnlyRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	var resp models.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, UnsupportedMediaTypeMessage, resp.Error)
+}
+
+func TestRequireJSONRejectsMissingContentType(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/save", strings.NewReader(`{"name":"Jane Doe"}`))
+	w := httptest.NewRecorder()
+	newJSONOnlyRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+}
+
+func TestRequireJSONAllowsJSONPost(t *testing.T) {
+	for _, contentType := range []string{"application/json", "application/json; charset=utf-8", "Application/JSON"} {
+		req := httptest.NewRequest(http.MethodPost, "/save", strings.NewReader(`{"name":"Jane Doe"}`))
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		newJSONOnlyRouter().ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code, contentType)
+	}
+}
+
+func TestRequireJSONAllowsRequestWithoutBody(t *testing.T) {
+	w := httptest.NewRecorder()
+	newJSONOnlyRouter().ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/7", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const DeprecationHeader = "Deprecation"
+
+// Deprecated marks responses from legacy routes as deprecated and links to
+// the same path under successorPrefix, which follows basePath when the
+// routes are mounted under one.
+func Deprecated(basePath, successorPrefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := strings.TrimPrefix(c.Request.URL.Path, basePath)
+		c.Header(DeprecationHeader, "true")
+		c.Header("Link", fmt.Sprintf("<%s%s%s>; rel=\"successor-version\"", basePath, successorPrefix, path))
+
+		c.Next()
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"person-service/models"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DrainingMessage is the error reported with 503 responses while the server
+// shuts down.
+const DrainingMessage = "Server is shutting down"
+
+// Drainer turns new requests away once shutdown begins, telling clients to
+// retry, presumably against another instance, instead of having their
+// connection dropped. Requests already past the middleware run to
+// completion.
+type Drainer struct {
+	retryAfter time.Duration
+	draining   atomic.Bool
+}
+
+func NewDrainer(retryAfter time.Duration) *Drainer {
+	return &Drainer{retryAfter: retryAfter}
+}
+
+// Start marks the server as draining. It is safe to call from any
+// goroutine, e.g. the one handling SIGTERM.
+func (d *Drainer) Start() {
+	d.draining.Store(true)
+}
+
+// Draining reports whether Start has been called.
+func (d *Drainer) Draining() bool {
+	return d.draining.Load()
+}
+
+// Middleware answers 503 with Retry-After while draining and closes the
+// connection, so keep-alive clients reconnect elsewhere.
+func (d *Drainer) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if d.Draining() {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(d.retryAfter.Seconds()))))
+			c.Header("Connection", "close")
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, models.ErrorResponse{
+				Error:     DrainingMessage,
+				RequestID: c.GetString(RequestIDKey),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
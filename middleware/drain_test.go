@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDrainingRouter(d *Drainer) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(d.Middleware())
+	router.GET("/persons", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestDrainerServesRequestsUntilStarted(t *testing.T) {
+	d := NewDrainer(5 * time.Second)
+
+	w := httptest.NewRecorder()
+	newDrainingRouter(d).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/persons", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Retry-After"))
+}
+
+func TestDrainerRejectsNewRequestsWhileDraining(t *testing.T) {
+	d := NewDrainer(5 * time.Second)
+	router := newDrainingRouter(d)
+	d.Start()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/persons", nil))
+
+	assert.True(t, d.Draining())
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "5", w.Header().Get("Retry-After"))
+	assert.Equal(t, "close", w.Header().Get("Connection"))
+	var resp models.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, DrainingMessage, resp.Error)
+}
+
+func TestDrainerLetsInFlightRequestsFinish(t *testing.T) {
+	d := NewDrainer(5 * time.Second)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(d.Middleware())
+	router.GET("/slow", func(c *gin.Context) {
+		// Shutdown begins while this request is being handled.
+		d.Start()
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CamelCaseJSON rewrites the object keys of JSON responses from snake_case
+// to camelCase, e.g. external_id to externalId, keeping key order and
+// values as they are. JSON bodies are held back until the handler returns;
+// other responses, such as XML, CSV or event streams, pass through as they
+// are written. Request bodies are not affected.
+func CamelCaseJSON() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writer := &camelCaseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		defer func() {
+			// Also runs while a panic unwinds, so Recovery answers it
+			// through the original writer.
+			c.Writer = writer.ResponseWriter
+		}()
+
+		c.Next()
+
+		if writer.body == nil {
+			return
+		}
+		body, err := camelCaseKeys(writer.body.Bytes())
+		if err != nil {
+			slog.ErrorContext(c.Request.Context(), "Failed to rewrite JSON response keys", "error", err)
+			body = writer.body.Bytes()
+		}
+		writer.ResponseWriter.Write(body)
+	}
+}
+
+// camelCaseWriter buffers the body once the handler has declared a JSON
+// content type. Status and headers go straight to the underlying writer,
+// which does not send them before the first write.
+type camelCaseWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *camelCaseWriter) Write(data []byte) (int, error) {
+	if w.body == nil && isJSON(w.Header().Get("Content-Type")) {
+		w.body = &bytes.Buffer{}
+	}
+	if w.body != nil {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *camelCaseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *camelCaseWriter) Written() bool {
+	return w.body != nil || w.ResponseWriter.Written()
+}
+
+// Flush is a no-op while a JSON body is buffered.
+func (w *camelCaseWriter) Flush() {
+	if w.body == nil {
+		w.ResponseWriter.Flush()
+	}
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/json"
+}
+
+// camelCaseKeys re-encodes a JSON document token by token, renaming object
+// keys with camelCase.
+func camelCaseKeys(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	// Each open object or array counts the keys and values written to it,
+	// which tells keys from values and where separators go.
+	type container struct {
+		object bool
+		tokens int
+	}
+	var stack []container
+	var out bytes.Buffer
+	for {
+		token, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return out.Bytes(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if delim, ok := token.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			out.WriteByte(byte(delim))
+			continue
+		}
+
+		isKey := false
+		if len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			switch {
+			case top.object && top.tokens%2 == 1:
+				out.WriteByte(':')
+			case top.tokens > 0:
+				out.WriteByte(',')
+			}
+			isKey = top.object && top.tokens%2 == 0
+			top.tokens++
+		}
+
+		switch v := token.(type) {
+		case json.Delim:
+			out.WriteByte(byte(v))
+			stack = append(stack, container{object: v == '{'})
+		case json.Number:
+			out.WriteString(v.String())
+		case string:
+			if isKey {
+				v = camelCase(v)
+			}
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			out.Write(encoded)
+		default:
+			// bool or nil
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			out.Write(encoded)
+		}
+	}
+}
+
+// camelCase turns a snake_case name into camelCase: created_last_7_days
+// becomes createdLast7Days.
+func camelCase(name string) string {
+	parts := strings.Split(name, "_")
+	var b strings.Builder
+	b.WriteString(parts[0])
+	for _, part := range parts[1:] {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newNamingRouter(middleware ...gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware...)
+	router.GET("/person", func(c *gin.Context) {
+		c.JSON(http.StatusCreated, models.PersonListResponse{
+			Data: []models.PersonResponse{{
+				ExternalID:  uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+				Name:        "Jane_Doe",
+				Email:       "jane@example.com",
+				DateOfBirth: models.DateOf(time.Date(1990, 4, 2, 0, 0, 0, 0, time.UTC)),
+				Age:         36,
+				Active:      true,
+			}},
+			Page:     1,
+			PageSize: 20,
+			Total:    9007199254740993,
+		})
+	})
+	router.GET("/person.xml", func(c *gin.Context) {
+		c.XML(http.StatusOK, models.PersonResponse{Name: "Jane"})
+	})
+	router.GET("/persons.csv", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/csv", []byte("external_id,name\n"))
+	})
+	return router
+}
+
+func TestCamelCaseJSONRenamesKeys(t *testing.T) {
+	w := httptest.NewRecorder()
+	newNamingRouter(CamelCaseJSON()).ServeHTTP(w, httptest.NewRequest("GET", "/person", nil))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"data": [{
+			"externalId": "550e8400-e29b-41d4-a716-446655440000",
+			"name": "Jane_Doe",
+			"email": "jane@example.com",
+			"dateOfBirth": "1990-04-02",
+			"age": 36,
+			"active": true,
+			"createdAt": "0001-01-01T00:00:00Z",
+			"updatedAt": "0001-01-01T00:00:00Z",
+			"version": 0
+		}],
+		"page": 1,
+		"pageSize": 20,
+		"total": 9007199254740993
+	}`, w.Body.String())
+	// Large numbers are copied verbatim rather than through float64.
+	assert.Contains(t, w.Body.String(), `"total":9007199254740993`)
+}
+
+func TestSnakeCaseIsTheDefault(t *testing.T) {
+	w := httptest.NewRecorder()
+	newNamingRouter().ServeHTTP(w, httptest.NewRequest("GET", "/person", nil))
+
+	assert.Contains(t, w.Body.String(), `"external_id":`)
+	assert.Contains(t, w.Body.String(), `"page_size":20`)
+	assert.NotContains(t, w.Body.String(), `externalId`)
+}
+
+func TestCamelCaseJSONLeavesOtherFormatsAlone(t *testing.T) {
+	router := newNamingRouter(CamelCaseJSON())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/person.xml", nil))
+	assert.Contains(t, w.Body.String(), "<external_id>")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/persons.csv", nil))
+	assert.Equal(t, "external_id,name\n", w.Body.String())
+}
+
+func TestCamelCase(t *testing.T) {
+	for name, want := range map[string]string{
+		"name":                 "name",
+		"external_id":          "externalId",
+		"created_last_7_days":  "createdLast7Days",
+		"address_postal_code":  "addressPostalCode",
+		"already_camelCase_ok": "alreadyCamelCaseOk",
+	} {
+		assert.Equal(t, want, camelCase(name), name)
+	}
+}
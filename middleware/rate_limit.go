@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"person-service/models"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+const (
+	rateLimitIdleTTL       = 3 * time.Minute
+	rateLimitSweepInterval = time.Minute
+)
+
+// RateLimiter keeps a token bucket per client IP. Buckets idle for longer
+// than rateLimitIdleTTL are swept so the map cannot grow without bound.
+type RateLimiter struct {
+	rps   rate.Limit
+	burst int
+	now   func() time.Time
+
+	mu        sync.Mutex
+	clients   map[string]*rateLimitClient
+	lastSweep time.Time
+}
+
+type rateLimitClient struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		rps:       rate.Limit(rps),
+		burst:     burst,
+		now:       time.Now,
+		clients:   make(map[string]*rateLimitClient),
+		lastSweep: time.Now(),
+	}
+}
+
+// Middleware rejects requests beyond the client's budget with 429 and a
+// Retry-After header giving the seconds until a token is available.
+func (rl *RateLimiter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		now := rl.now()
+		reservation := rl.limiter(c.ClientIP(), now).ReserveN(now, 1)
+		if delay := reservation.DelayFrom(now); !reservation.OK() || delay > 0 {
+			reservation.CancelAt(now)
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, models.ErrorResponse{
+				Error:     "rate limit exceeded",
+				RequestID: c.GetString(RequestIDKey),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Clients reports how many client buckets are currently tracked.
+func (rl *RateLimiter) Clients() int {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return len(rl.clients)
+}
+
+func (rl *RateLimiter) limiter(ip string, now time.Time) *rate.Limiter {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if now.Sub(rl.lastSweep) >= rateLimitSweepInterval {
+		for key, client := range rl.clients {
+			if now.Sub(client.lastSeen) > rateLimitIdleTTL {
+				delete(rl.clients, key)
+			}
+		}
+		rl.lastSweep = now
+	}
+
+	client, ok := rl.clients[ip]
+	if !ok {
+		client = &rateLimitClient{limiter: rate.NewLimiter(rl.rps, rl.burst)}
+		rl.clients[ip] = client
+	}
+	client.lastSeen = now
+	return client.limiter
+}
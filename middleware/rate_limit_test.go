@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRateLimitedRouter(limiter *RateLimiter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(limiter.Middleware())
+	router.POST("/save", func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+	return router
+}
+
+func postFrom(router *gin.Engine, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/save", nil)
+	req.RemoteAddr = remoteAddr
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitRejectsRequestsPastBurst(t *testing.T) {
+	router := newRateLimitedRouter(NewRateLimiter(1, 3))
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusCreated, postFrom(router, "10.0.0.1:1234").Code)
+	}
+
+	w := postFrom(router, "10.0.0.1:1234")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	var resp models.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "rate limit exceeded", resp.Error)
+
+	assert.Equal(t, http.StatusCreated, postFrom(router, "10.0.0.2:1234").Code, "other clients keep their own budget")
+}
+
+func TestRateLimitSweepsIdleClients(t *testing.T) {
+	limiter := NewRateLimiter(1, 1)
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+	router := newRateLimitedRouter(limiter)
+
+	postFrom(router, "10.0.0.1:1234")
+	postFrom(router, "10.0.0.2:1234")
+	assert.Equal(t, 2, limiter.Clients())
+
+	now = now.Add(rateLimitIdleTTL + time.Second)
+	postFrom(router, "10.0.0.3:1234")
+	assert.Equal(t, 1, limiter.Clients())
+}
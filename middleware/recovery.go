@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"person-service/models"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InternalErrorMessage is the error reported when a handler panics.
+const InternalErrorMessage = "internal server error"
+
+// Recovery turns a panic in a later handler into a 500 ErrorResponse and
+// logs it with the stack trace and, via the request context, the request
+// ID. If the response has already started it is cut short instead.
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// net/http uses this panic to abort a response on purpose.
+			if err, ok := recovered.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(recovered)
+			}
+
+			slog.ErrorContext(c.Request.Context(), "Recovered from panic", "panic", recovered, "stack", string(debug.Stack()))
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:     InternalErrorMessage,
+				RequestID: c.GetString(RequestIDKey),
+			})
+		}()
+
+		c.Next()
+	}
+}
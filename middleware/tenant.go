@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"person-service/database"
+	"person-service/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	TenantHeader = "X-Tenant-ID"
+	TenantKey    = "tenant_id"
+)
+
+// Tenant requires an X-Tenant-ID header holding a UUID and attaches the
+// tenant to the request context, which scopes every database query made
+// with it to that tenant.
+func Tenant() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value := c.GetHeader(TenantHeader)
+		if value == "" {
+			abortBadTenant(c, "Missing X-Tenant-ID header")
+			return
+		}
+
+		tenant, err := uuid.Parse(value)
+		if err != nil {
+			abortBadTenant(c, "Invalid X-Tenant-ID header: must be a UUID")
+			return
+		}
+
+		c.Set(TenantKey, tenant)
+		c.Request = c.Request.WithContext(database.WithTenant(c.Request.Context(), tenant))
+
+		c.Next()
+	}
+}
+
+func abortBadTenant(c *gin.Context, message string) {
+	c.AbortWithStatusJSON(http.StatusBadRequest, models.ErrorResponse{
+		Error:     message,
+		RequestID: c.GetString(RequestIDKey),
+	})
+}
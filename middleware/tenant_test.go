@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/database"
+	"person-service/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTenantRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Tenant())
+	router.GET("/persons", func(c *gin.Context) {
+		tenant, ok := database.TenantFromContext(c.Request.Context())
+		if !ok {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.String(http.StatusOK, tenant.String())
+	})
+	return router
+}
+
+func TestTenantAttachesTenantToContext(t *testing.T) {
+	router := newTenantRouter()
+	tenant := uuid.New()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/persons", nil)
+	req.Header.Set(TenantHeader, tenant.String())
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, tenant.String(), w.Body.String())
+}
+
+func TestTenantRejectsMissingOrInvalidHeader(t *testing.T) {
+	router := newTenantRouter()
+
+	tests := []struct {
+		name    string
+		header  string
+		message string
+	}{
+		{"missing", "", "Missing X-Tenant-ID header"},
+		{"not a uuid", "acme", "Invalid X-Tenant-ID header: must be a UUID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/persons", nil)
+			if tt.header != "" {
+				req.Header.Set(TenantHeader, tt.header)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			var response models.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.message, response.Error)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"person-service/database"
+	"person-service/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Transaction runs each request in a database transaction attached to the
+// request context, which handlers and the repository join through
+// database.Conn. The transaction commits when the response status is 2xx
+// and rolls back on any other status or a panic. The response is buffered
+// until the commit succeeds, so a client never sees a success that was
+// rolled back; a failed commit is answered with 500 instead. Side effects
+// registered with database.AfterCommit run only once the commit succeeded.
+// Buffering makes it unsuitable for streaming routes, and it is opt-in per
+// route group so reads do not pay for a transaction.
+func Transaction(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		tx := db.WithContext(ctx).Begin()
+		if tx.Error != nil {
+			slog.ErrorContext(ctx, "Failed to begin request transaction", "error", tx.Error)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:     InternalErrorMessage,
+				RequestID: c.GetString(RequestIDKey),
+			})
+			return
+		}
+
+		writer := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		txCtx, committed := database.WithTx(ctx, tx)
+		c.Request = c.Request.WithContext(txCtx)
+
+		done := false
+		defer func() {
+			// Also runs while a panic unwinds, before Recovery answers it
+			// through the original writer.
+			c.Writer = writer.ResponseWriter
+			if !done {
+				tx.Rollback()
+			}
+		}()
+
+		c.Next()
+
+		if status := writer.Status(); status < http.StatusOK || status >= http.StatusMultipleChoices {
+			tx.Rollback()
+			done = true
+			writer.flush()
+			return
+		}
+		if err := tx.Commit().Error; err != nil {
+			slog.ErrorContext(ctx, "Failed to commit request transaction", "error", err)
+			done = true
+			c.Writer = writer.ResponseWriter
+			c.AbortWithStatusJSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error:     InternalErrorMessage,
+				RequestID: c.GetString(RequestIDKey),
+			})
+			return
+		}
+		done = true
+		writer.flush()
+		committed()
+	}
+}
+
+// bufferedWriter holds back the status and body written by handlers until
+// flush. Headers go straight to the underlying writer's header map, which
+// is not sent before the status is.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	if !w.Written() {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.status != 0 || w.body.Len() > 0
+}
+
+// Flush is a no-op: nothing may reach the client before the commit.
+func (w *bufferedWriter) Flush() {}
+
+func (w *bufferedWriter) flush() {
+	if !w.Written() {
+		return
+	}
+	w.ResponseWriter.WriteHeader(w.Status())
+	w.ResponseWriter.WriteHeaderNow()
+	if w.body.Len() > 0 {
+		w.ResponseWriter.Write(w.body.Bytes())
+	}
+}
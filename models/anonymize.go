@@ -0,0 +1,27 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RedactedName replaces the name of an anonymized person.
+const RedactedName = "REDACTED"
+
+// anonymizedEmailDomain is reserved by RFC 2606, so the placeholder emails
+// can never reach anyone.
+const anonymizedEmailDomain = "anonymized.invalid"
+
+// AnonymizedDateOfBirth replaces the date of birth of an anonymized person.
+var AnonymizedDateOfBirth = DateOf(time.Unix(0, 0).UTC())
+
+// AnonymizedEmail is the placeholder email of an anonymized person. It is
+// derived from the external ID rather than the old email, so it reveals
+// nothing about the person, yet stays unique and the same on every run.
+func AnonymizedEmail(externalID uuid.UUID) string {
+	sum := sha256.Sum256(externalID[:])
+	return "redacted-" + hex.EncodeToString(sum[:8]) + "@" + anonymizedEmailDomain
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnonymizedEmailIsDeterministicPerPerson(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+
+	assert.Equal(t, AnonymizedEmail(a), AnonymizedEmail(a))
+	assert.NotEqual(t, AnonymizedEmail(a), AnonymizedEmail(b))
+	assert.Regexp(t, `^redacted-[0-9a-f]{16}@anonymized\.invalid$`, AnonymizedEmail(a))
+	assert.NotContains(t, AnonymizedEmail(a), a.String())
+}
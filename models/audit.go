@@ -0,0 +1,55 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Actions recorded in AuditEntry.Action.
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditDelete = "delete"
+	// AuditAnonymize replaces a person's personal data; the snapshots of
+	// the person's earlier entries are cleared along with it.
+	AuditAnonymize = "anonymize"
+)
+
+// AuditEntry records one change to a person as JSON snapshots of the row
+// before and after it. Before is null for creates and After for deletes.
+type AuditEntry struct {
+	ID        uint            `json:"-" gorm:"primaryKey"`
+	PersonID  uint            `json:"person_id" gorm:"not null"`
+	Action    string          `json:"action" gorm:"size:16;not null"`
+	Before    json.RawMessage `json:"before" gorm:"type:jsonb" swaggertype:"object"`
+	After     json.RawMessage `json:"after" gorm:"type:jsonb" swaggertype:"object"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func (AuditEntry) TableName() string {
+	return "audit"
+}
+
+// NewAuditEntry snapshots before and after, either of which may be nil.
+func NewAuditEntry(action string, before, after *Person) (AuditEntry, error) {
+	entry := AuditEntry{Action: action}
+
+	var err error
+	if before != nil {
+		entry.PersonID = before.ID
+		if entry.Before, err = json.Marshal(before); err != nil {
+			return AuditEntry{}, err
+		}
+	}
+	if after != nil {
+		entry.PersonID = after.ID
+		if entry.After, err = json.Marshal(after); err != nil {
+			return AuditEntry{}, err
+		}
+	}
+	return entry, nil
+}
+
+type HistoryResponse struct {
+	Data []AuditEntry `json:"data"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAuditEntrySnapshotsBeforeAndAfter(t *testing.T) {
+	before := Person{ID: 7, Name: "Jane", Version: 1}
+	after := Person{ID: 7, Name: "Janet", Version: 2}
+
+	entry, err := NewAuditEntry(AuditUpdate, &before, &after)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), entry.PersonID)
+	assert.Equal(t, AuditUpdate, entry.Action)
+
+	var decoded Person
+	require.NoError(t, json.Unmarshal(entry.Before, &decoded))
+	assert.Equal(t, "Jane", decoded.Name)
+	require.NoError(t, json.Unmarshal(entry.After, &decoded))
+	assert.Equal(t, "Janet", decoded.Name)
+}
+
+func TestNewAuditEntryLeavesMissingSideNull(t *testing.T) {
+	entry, err := NewAuditEntry(AuditCreate, nil, &Person{ID: 3})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), entry.PersonID)
+	assert.Nil(t, entry.Before)
+
+	body, err := json.Marshal(entry)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"before":null`)
+}
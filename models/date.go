@@ -0,0 +1,79 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// Date is a calendar date without a time of day, held as midnight UTC. It
+// is written as "2006-01-02" in JSON, XML and SQL and read from either that
+// form or, for older clients, an RFC 3339 timestamp, whose date as written
+// is kept and whose time of day is dropped.
+type Date time.Time
+
+func NewDate(year int, month time.Month, day int) Date {
+	return Date(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// DateOf returns the date of t in t's location.
+func DateOf(t time.Time) Date {
+	return NewDate(t.Date())
+}
+
+// ParseDate accepts a YYYY-MM-DD date or an RFC 3339 timestamp.
+func ParseDate(value string) (Date, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return Date(t), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q: must be YYYY-MM-DD or an RFC 3339 timestamp", value)
+	}
+	return DateOf(t), nil
+}
+
+// Time returns the date as midnight UTC.
+func (d Date) Time() time.Time {
+	return time.Time(d)
+}
+
+func (d Date) IsZero() bool {
+	return d.Time().IsZero()
+}
+
+func (d Date) String() string {
+	return d.Time().Format(time.DateOnly)
+}
+
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Date) UnmarshalText(text []byte) error {
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Value stores the date as text so PostgreSQL reads it as a date rather
+// than converting a timestamp in the session time zone.
+func (d Date) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+func (d *Date) Scan(value any) error {
+	switch v := value.(type) {
+	case time.Time:
+		*d = DateOf(v)
+		return nil
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case []byte:
+		return d.UnmarshalText(v)
+	}
+	return fmt.Errorf("cannot scan %T into Date", value)
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateUnmarshalJSONAcceptsDateOnly(t *testing.T) {
+	var req SavePersonRequest
+	require.NoError(t, json.Unmarshal([]byte(`{"date_of_birth":"1990-04-02"}`), &req))
+
+	assert.Equal(t, NewDate(1990, time.April, 2), req.DateOfBirth)
+}
+
+func TestDateUnmarshalJSONAcceptsTimestamp(t *testing.T) {
+	var req SavePersonRequest
+	require.NoError(t, json.Unmarshal([]byte(`{"date_of_birth":"1990-04-02T23:30:00-05:00"}`), &req))
+
+	// The date as written is kept, not the UTC date (April 3rd).
+	assert.Equal(t, NewDate(1990, time.April, 2), req.DateOfBirth)
+}
+
+func TestDateUnmarshalJSONRejectsGarbage(t *testing.T) {
+	var req SavePersonRequest
+	err := json.Unmarshal([]byte(`{"date_of_birth":"02.04.1990"}`), &req)
+
+	assert.ErrorContains(t, err, "must be YYYY-MM-DD or an RFC 3339 timestamp")
+}
+
+func TestDateMarshalsAsDateOnly(t *testing.T) {
+	person := Person{DateOfBirth: NewDate(1990, time.April, 2)}
+
+	body, err := json.Marshal(person.ToResponse())
+	require.NoError(t, err)
+
+	assert.Contains(t, string(body), `"date_of_birth":"1990-04-02"`)
+}
+
+func TestDateScan(t *testing.T) {
+	var d Date
+	require.NoError(t, d.Scan(time.Date(1990, time.April, 2, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, NewDate(1990, time.April, 2), d)
+
+	value, err := d.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "1990-04-02", value)
+}
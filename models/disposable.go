@@ -0,0 +1,72 @@
+package models
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//go:embed disposable_domains.txt
+var defaultDisposableDomains string
+
+// disposableDomains holds the blocked email domains; nil disables the check.
+var disposableDomains map[string]struct{}
+
+// DefaultDisposableDomains returns the built-in list of disposable email
+// domains.
+func DefaultDisposableDomains() []string {
+	domains, _ := ParseDomainList(strings.NewReader(defaultDisposableDomains))
+	return domains
+}
+
+// ParseDomainList reads one domain per line, skipping blank lines and
+// comments starting with #.
+func ParseDomainList(r io.Reader) ([]string, error) {
+	var domains []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, strings.ToLower(line))
+	}
+	return domains, scanner.Err()
+}
+
+// SetDisposableDomains makes the request Validate methods reject emails at
+// any of domains or their subdomains; an empty list disables the check. Call
+// it once at startup, before serving requests.
+func SetDisposableDomains(domains []string) {
+	if len(domains) == 0 {
+		disposableDomains = nil
+		return
+	}
+	disposableDomains = make(map[string]struct{}, len(domains))
+	for _, domain := range domains {
+		disposableDomains[domain] = struct{}{}
+	}
+}
+
+func validateEmailDomain(email string) error {
+	if disposableDomains == nil {
+		return nil
+	}
+
+	_, domain, ok := strings.Cut(NormalizeEmail(email), "@")
+	if !ok {
+		return nil
+	}
+	for {
+		if _, blocked := disposableDomains[domain]; blocked {
+			return fmt.Errorf("email domain %s is not allowed: disposable addresses are rejected", domain)
+		}
+		_, parent, ok := strings.Cut(domain, ".")
+		if !ok {
+			return nil
+		}
+		domain = parent
+	}
+}
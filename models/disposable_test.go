@@ -0,0 +1,58 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rejectDisposableEmail(t *testing.T) {
+	t.Helper()
+	SetDisposableDomains(DefaultDisposableDomains())
+	t.Cleanup(func() { SetDisposableDomains(nil) })
+}
+
+func TestValidateAcceptsDisposableEmailByDefault(t *testing.T) {
+	req := validSaveRequest()
+	req.Email = "someone@mailinator.com"
+
+	assert.NoError(t, req.Validate())
+}
+
+func TestValidateRejectsDisposableEmailWhenEnabled(t *testing.T) {
+	rejectDisposableEmail(t)
+
+	for _, email := range []string{"someone@mailinator.com", "Someone@YopMail.com", "someone@inbox.tempmail.com"} {
+		req := validSaveRequest()
+		req.Email = email
+
+		assert.ErrorContains(t, req.Validate(), "disposable addresses are rejected", email)
+	}
+}
+
+func TestValidateAcceptsNormalEmailWhenEnabled(t *testing.T) {
+	rejectDisposableEmail(t)
+
+	req := validSaveRequest()
+	req.Email = "someone@example.com"
+
+	assert.NoError(t, req.Validate())
+}
+
+func TestPatchValidateRejectsDisposableEmail(t *testing.T) {
+	rejectDisposableEmail(t)
+
+	email := "someone@guerrillamail.com"
+	req := PatchPersonRequest{Email: &email}
+
+	assert.ErrorContains(t, req.Validate(), "disposable addresses are rejected")
+}
+
+func TestParseDomainListSkipsCommentsAndBlankLines(t *testing.T) {
+	domains, err := ParseDomainList(strings.NewReader("# comment\n\nMailinator.com\n  yopmail.com  \n"))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mailinator.com", "yopmail.com"}, domains)
+}
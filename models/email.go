@@ -0,0 +1,97 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxEmails caps the addresses, the primary included, a person can be saved
+// with.
+const maxEmails = 10
+
+// PersonEmail is a row of the person_emails table. Every person has one
+// primary row mirroring Person.Email, kept in step by database triggers;
+// Person.Emails only holds the others.
+type PersonEmail struct {
+	ID        uint      `json:"-" gorm:"primaryKey"`
+	PersonID  uint      `json:"-" gorm:"not null"`
+	Email     string    `json:"email" gorm:"not null"`
+	Primary   bool      `json:"primary" gorm:"column:is_primary;not null;default:false"`
+	CreatedAt time.Time `json:"-"`
+}
+
+// EmailsChange is the key under which UpdatePersonRequest.Changes returns
+// the addresses replacing a person's others, as []PersonEmail. It is not a
+// column: the repository replaces the person_emails rows with it.
+const EmailsChange = "emails"
+
+// EmailAddress is one of a person's email addresses in requests and
+// responses. In XML it renders as <email primary="true">...</email>.
+type EmailAddress struct {
+	Email   string `json:"email" xml:",chardata" binding:"required,email"`
+	Primary bool   `json:"primary" xml:"primary,attr"`
+}
+
+// primaryEmail returns the address marked primary, or "" when none is.
+func primaryEmail(emails []EmailAddress) string {
+	for _, e := range emails {
+		if e.Primary {
+			return e.Email
+		}
+	}
+	return ""
+}
+
+// validateEmails checks the emails of a save request: exactly one primary,
+// no address twice, and a legacy email, if given, matching the primary.
+func validateEmails(email string, emails []EmailAddress) error {
+	if len(emails) > maxEmails {
+		return fmt.Errorf("emails cannot hold more than %d addresses", maxEmails)
+	}
+
+	primaries := 0
+	seen := make(map[string]bool, len(emails))
+	for _, e := range emails {
+		if e.Primary {
+			primaries++
+		}
+		address := NormalizeEmail(e.Email)
+		if seen[address] {
+			return fmt.Errorf("emails lists %s more than once", address)
+		}
+		seen[address] = true
+		if err := validateEmailDomain(address); err != nil {
+			return err
+		}
+	}
+	if primaries != 1 {
+		return errors.New("emails must have exactly one primary address")
+	}
+	if email != "" && NormalizeEmail(email) != NormalizeEmail(primaryEmail(emails)) {
+		return errors.New("email must match the primary address in emails")
+	}
+	return nil
+}
+
+// secondaryEmails turns the non-primary addresses of a request into rows to
+// create with the person.
+func secondaryEmails(emails []EmailAddress) []PersonEmail {
+	var rows []PersonEmail
+	for _, e := range emails {
+		if !e.Primary {
+			rows = append(rows, PersonEmail{Email: NormalizeEmail(e.Email)})
+		}
+	}
+	return rows
+}
+
+// EmailAddresses returns the person's email followed by its other
+// addresses.
+func (p Person) EmailAddresses() []string {
+	addresses := []string{p.Email}
+	for _, e := range p.Emails {
+		addresses = append(addresses, e.Email)
+	}
+	return addresses
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRequiresExactlyOnePrimaryEmail(t *testing.T) {
+	for name, emails := range map[string][]EmailAddress{
+		"none": {{Email: "john@example.com"}, {Email: "john@work.example"}},
+		"two":  {{Email: "john@example.com", Primary: true}, {Email: "john@work.example", Primary: true}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := validSaveRequest()
+			req.Emails = emails
+
+			assert.EqualError(t, req.Validate(), "emails must have exactly one primary address")
+		})
+	}
+}
+
+func TestValidateRejectsRepeatedEmail(t *testing.T) {
+	req := validSaveRequest()
+	req.Emails = []EmailAddress{{Email: "john@example.com", Primary: true}, {Email: " John@Example.com"}}
+
+	assert.EqualError(t, req.Validate(), "emails lists john@example.com more than once")
+}
+
+func TestValidateRejectsEmailOtherThanPrimary(t *testing.T) {
+	req := validSaveRequest()
+	req.Emails = []EmailAddress{{Email: "john@work.example", Primary: true}}
+
+	assert.EqualError(t, req.Validate(), "email must match the primary address in emails")
+}
+
+func TestFromSaveRequestTakesPrimaryFromEmails(t *testing.T) {
+	req := validSaveRequest()
+	req.Email = ""
+	req.Emails = []EmailAddress{{Email: "john@work.example"}, {Email: "John@Example.com", Primary: true}}
+	assert.NoError(t, req.Validate())
+
+	person := FromSaveRequest(req)
+
+	assert.Equal(t, "john@example.com", person.Email)
+	assert.Equal(t, []PersonEmail{{Email: "john@work.example"}}, person.Emails)
+	assert.Equal(t, []EmailAddress{
+		{Email: "john@example.com", Primary: true},
+		{Email: "john@work.example"},
+	}, person.ToResponse().Emails)
+}
+
+func TestToResponseListsPrimaryEmailWithoutOthers(t *testing.T) {
+	person := FromSaveRequest(validSaveRequest())
+
+	assert.Equal(t, []EmailAddress{{Email: "john@example.com", Primary: true}}, person.ToResponse().Emails)
+}
+
+func TestUpdateChangesReplaceOtherEmails(t *testing.T) {
+	req := UpdatePersonRequest{
+		Name:   "John Doe",
+		Emails: []EmailAddress{{Email: "John@Example.com", Primary: true}, {Email: "john@work.example"}},
+	}
+
+	changes := req.Changes()
+
+	assert.Equal(t, "john@example.com", changes["email"])
+	assert.Equal(t, []PersonEmail{{Email: "john@work.example"}}, changes[EmailsChange])
+}
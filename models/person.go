@@ -1,77 +1,620 @@
 package models
 
 import (
+	"encoding/xml"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	"golang.org/x/text/unicode/norm"
 	"gorm.io/gorm"
 )
 
+const (
+	minBirthYear  = 1900
+	maxNameLength = 100
+)
+
+// Names of the unique indexes declared on Person; they must match the gorm
+// tags below and the migrations in database/migrations. Each is scoped to
+// the tenant, so the same value may exist once per tenant.
+const (
+	ExternalIDIndex     = "idx_people_external_id"
+	EmailIndex          = "idx_people_email"
+	IdempotencyKeyIndex = "idx_people_idempotency_key"
+)
+
 type Person struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`
-	ExternalID  uuid.UUID `json:"external_id" gorm:"type:uuid;unique;not null"`
-	Name        string    `json:"name" gorm:"not null"`
-	Email       string    `json:"email" gorm:"not null"`
-	DateOfBirth time.Time `json:"date_of_birth" gorm:"not null"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID             uint           `json:"id" gorm:"primaryKey"`
+	TenantID       uuid.UUID      `json:"tenant_id" gorm:"type:uuid;not null;uniqueIndex:idx_people_external_id,priority:1;uniqueIndex:idx_people_email,priority:1;uniqueIndex:idx_people_idempotency_key,priority:1"`
+	ExternalID     uuid.UUID      `json:"external_id" gorm:"type:uuid;not null;uniqueIndex:idx_people_external_id,priority:2,where:deleted_at IS NULL"`
+	Name           string         `json:"name" gorm:"not null"`
+	FirstName      string         `json:"first_name,omitempty" gorm:"size:100"`
+	MiddleName     string         `json:"middle_name,omitempty" gorm:"size:100"`
+	LastName       string         `json:"last_name,omitempty" gorm:"size:100"`
+	SearchName     string         `json:"-" gorm:"not null"`
+	Active         bool           `json:"active" gorm:"not null;default:true"`
+	Email          string         `json:"email" gorm:"not null;uniqueIndex:idx_people_email,priority:2,where:deleted_at IS NULL"`
+	Phone          string         `json:"phone,omitempty" gorm:"size:16"`
+	Address        Address        `json:"address" gorm:"embedded;embeddedPrefix:address_"`
+	DateOfBirth    Date           `json:"date_of_birth" gorm:"type:date;not null" swaggertype:"string" format:"date"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	AnonymizedAt   *time.Time     `json:"anonymized_at,omitempty"`
+	IdempotencyKey *string        `json:"-" gorm:"size:255;uniqueIndex:idx_people_idempotency_key,priority:2,where:deleted_at IS NULL"`
+	Version        int            `json:"version" gorm:"not null;default:0"`
+	// Emails holds the addresses besides Email, oldest first. Creates save
+	// them along with the person; reads load them with
+	// database.PreloadEmails.
+	Emails []PersonEmail `json:"emails,omitempty" gorm:"foreignKey:PersonID"`
 }
 
+// Address is stored as address_* columns on the people table. All parts are
+// optional; Country is an ISO 3166-1 alpha-2 code such as "DE".
+type Address struct {
+	Street     string `json:"street,omitempty" xml:"street,omitempty" gorm:"size:200"`
+	City       string `json:"city,omitempty" xml:"city,omitempty" gorm:"size:100"`
+	Region     string `json:"region,omitempty" xml:"region,omitempty" gorm:"size:100"`
+	PostalCode string `json:"postal_code,omitempty" xml:"postal_code,omitempty" gorm:"size:20"`
+	Country    string `json:"country,omitempty" xml:"country,omitempty" gorm:"size:2" binding:"omitempty,iso3166_1_alpha2"`
+}
+
+// SavePersonRequest takes either a full name, structured name parts, or
+// both; without a name, the parts joined by spaces become the name. Emails
+// may list further addresses; email can then be left out, as the one marked
+// primary becomes the person's email.
 type SavePersonRequest struct {
-	ExternalID  uuid.UUID `json:"external_id" binding:"required"`
-	Name        string    `json:"name" binding:"required"`
-	Email       string    `json:"email" binding:"required,email"`
-	DateOfBirth time.Time `json:"date_of_birth" binding:"required"`
+	ExternalID  uuid.UUID      `json:"external_id" binding:"required"`
+	Name        string         `json:"name" binding:"required_without_all=FirstName LastName"`
+	FirstName   string         `json:"first_name"`
+	MiddleName  string         `json:"middle_name"`
+	LastName    string         `json:"last_name"`
+	Email       string         `json:"email" binding:"required_without=Emails,omitempty,email"`
+	Emails      []EmailAddress `json:"emails" binding:"omitempty,dive"`
+	Phone       string         `json:"phone" binding:"omitempty,e164"`
+	Address     *Address       `json:"address"`
+	DateOfBirth Date           `json:"date_of_birth" binding:"required" swaggertype:"string" format:"date" example:"1990-01-01"`
+}
+
+// UpdatePersonRequest replaces every field, including the name parts and
+// the addresses besides the primary email, which are cleared when left out.
+// As on save, email may be left out when emails marks a primary.
+type UpdatePersonRequest struct {
+	Name        string         `json:"name" binding:"required_without_all=FirstName LastName"`
+	FirstName   string         `json:"first_name"`
+	MiddleName  string         `json:"middle_name"`
+	LastName    string         `json:"last_name"`
+	Email       string         `json:"email" binding:"required_without=Emails,omitempty,email"`
+	Emails      []EmailAddress `json:"emails" binding:"omitempty,dive"`
+	Phone       string         `json:"phone" binding:"omitempty,e164"`
+	Address     *Address       `json:"address"`
+	DateOfBirth Date           `json:"date_of_birth" binding:"required" swaggertype:"string" format:"date" example:"1990-01-01"`
+}
+
+type PatchPersonRequest struct {
+	Name        *string  `json:"name"`
+	FirstName   *string  `json:"first_name"`
+	MiddleName  *string  `json:"middle_name"`
+	LastName    *string  `json:"last_name"`
+	Email       *string  `json:"email" binding:"omitempty,email"`
+	Phone       *string  `json:"phone" binding:"omitempty,e164"`
+	Address     *Address `json:"address"`
+	DateOfBirth *Date    `json:"date_of_birth" swaggertype:"string" format:"date" example:"1990-01-01"`
 }
 
 type PersonResponse struct {
-	ExternalID  uuid.UUID `json:"external_id"`
-	Name        string    `json:"name"`
-	Email       string    `json:"email"`
-	DateOfBirth time.Time `json:"date_of_birth"`
+	XMLName     xml.Name       `json:"-" xml:"person"`
+	ExternalID  uuid.UUID      `json:"external_id" xml:"external_id"`
+	Name        string         `json:"name" xml:"name"`
+	FirstName   string         `json:"first_name,omitempty" xml:"first_name,omitempty"`
+	MiddleName  string         `json:"middle_name,omitempty" xml:"middle_name,omitempty"`
+	LastName    string         `json:"last_name,omitempty" xml:"last_name,omitempty"`
+	Email       string         `json:"email" xml:"email"`
+	Emails      []EmailAddress `json:"emails,omitempty" xml:"emails>email,omitempty"`
+	Phone       string         `json:"phone,omitempty" xml:"phone,omitempty"`
+	Address     *Address       `json:"address,omitempty" xml:"address,omitempty"`
+	DateOfBirth Date           `json:"date_of_birth" xml:"date_of_birth" swaggertype:"string" format:"date"`
+	Age         int            `json:"age" xml:"age"`
+	Active      bool           `json:"active" xml:"active"`
+	Version     int            `json:"version" xml:"version"`
+	CreatedAt   time.Time      `json:"created_at" xml:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at" xml:"updated_at"`
+	DeletedAt   *time.Time     `json:"deleted_at,omitempty" xml:"deleted_at,omitempty"`
+	// AnonymizedAt is set once the person's personal data was replaced with
+	// placeholders.
+	AnonymizedAt *time.Time `json:"anonymized_at,omitempty" xml:"anonymized_at,omitempty"`
+}
+
+// PersonListResponse is rendered in XML as a <persons> element holding one
+// <person> per entry.
+type PersonListResponse struct {
+	XMLName  xml.Name         `json:"-" xml:"persons"`
+	Data     []PersonResponse `json:"data" xml:"person"`
+	Page     int              `json:"page" xml:"page"`
+	PageSize int              `json:"page_size" xml:"page_size"`
+	Total    int64            `json:"total" xml:"total"`
+}
+
+// PersonCursorResponse is a page of the cursor-paginated person list. Pass
+// NextCursor as ?cursor= to fetch the following page; it is empty on the
+// last page.
+type PersonCursorResponse struct {
+	XMLName    xml.Name         `json:"-" xml:"persons"`
+	Data       []PersonResponse `json:"data" xml:"person"`
+	Limit      int              `json:"limit" xml:"limit"`
+	NextCursor string           `json:"next_cursor,omitempty" xml:"next_cursor,omitempty"`
+}
+
+// PersonsByIDResponse holds the persons fetched with ?ids=, in the requested
+// order. IDs with no person are listed in Missing instead.
+type PersonsByIDResponse struct {
+	XMLName xml.Name         `json:"-" xml:"persons"`
+	Data    []PersonResponse `json:"data" xml:"person"`
+	Missing []uint           `json:"missing" xml:"missing>id"`
+}
+
+// LookupRequest lists the external IDs to resolve with POST /persons/lookup.
+type LookupRequest struct {
+	ExternalIDs []uuid.UUID `json:"external_ids"`
+}
+
+// LookupResponse holds the persons found by LookupRequest, in the requested
+// order. External IDs with no person are listed in Missing instead.
+type LookupResponse struct {
+	XMLName xml.Name         `json:"-" xml:"persons"`
+	Data    []PersonResponse `json:"data" xml:"person"`
+	Missing []uuid.UUID      `json:"missing" xml:"missing>external_id"`
+}
+
+// UpdateStatusRequest activates or deactivates a person.
+type UpdateStatusRequest struct {
+	Active *bool `json:"active" binding:"required"`
+}
+
+type CountResponse struct {
+	Count int64 `json:"count"`
+}
+
+type BulkDeleteResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// PersonStatsResponse aggregates the non-deleted persons. AverageAge is in
+// years, rounded to one decimal, and null when there are no persons.
+type PersonStatsResponse struct {
+	Total             int64         `json:"total"`
+	ByDecade          []DecadeCount `json:"by_decade"`
+	AverageAge        *float64      `json:"average_age"`
+	CreatedLast7Days  int64         `json:"created_last_7_days"`
+	CreatedLast30Days int64         `json:"created_last_30_days"`
+}
+
+// DecadeCount is the number of persons born in the decade starting with the
+// year Decade, e.g. 1980 for 1980-1989.
+type DecadeCount struct {
+	Decade int   `json:"decade"`
+	Count  int64 `json:"count"`
+}
+
+type BatchItemResult struct {
+	Index   int             `json:"index"`
+	Status  int             `json:"status"`
+	Person  *PersonResponse `json:"person,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Details []FieldError    `json:"details,omitempty"`
+}
+
+type BatchSaveResponse struct {
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+	Results []BatchItemResult `json:"results"`
+}
+
+type ImportError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+type ImportSummary struct {
+	Imported int           `json:"imported"`
+	Skipped  int           `json:"skipped"`
+	Errors   []ImportError `json:"errors"`
 }
 
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Error     string       `json:"error"`
+	RequestID string       `json:"request_id,omitempty"`
+	Details   []FieldError `json:"details,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
+// Validate rejects the nil UUID as external_id: clients must choose their
+// own ID, and server-side generation is reserved for internally created
+// records.
 func (r *SavePersonRequest) Validate() error {
-	name := strings.TrimSpace(r.Name)
-	if len(name) == 0 {
-		return errors.New("name cannot be empty")
+	if r.ExternalID == uuid.Nil {
+		return errors.New("external_id must not be the nil UUID")
+	}
+	if err := validateNameParts(r.FirstName, r.MiddleName, r.LastName); err != nil {
+		return err
+	}
+	if r.Emails != nil {
+		if err := validateEmails(r.Email, r.Emails); err != nil {
+			return err
+		}
+	}
+	return validatePersonFields(fullNameOr(r.Name, r.FirstName, r.MiddleName, r.LastName), r.PrimaryEmail(), r.DateOfBirth)
+}
+
+// PrimaryEmail returns the email the person is saved with: email, or the
+// primary address in emails when email was left out.
+func (r *SavePersonRequest) PrimaryEmail() string {
+	if r.Email == "" {
+		return primaryEmail(r.Emails)
+	}
+	return r.Email
+}
+
+func (r *UpdatePersonRequest) Validate() error {
+	if err := validateNameParts(r.FirstName, r.MiddleName, r.LastName); err != nil {
+		return err
+	}
+	if r.Emails != nil {
+		if err := validateEmails(r.Email, r.Emails); err != nil {
+			return err
+		}
+	}
+	return validatePersonFields(fullNameOr(r.Name, r.FirstName, r.MiddleName, r.LastName), r.PrimaryEmail(), r.DateOfBirth)
+}
+
+// PrimaryEmail returns the email the person is updated to: email, or the
+// primary address in emails when email was left out.
+func (r *UpdatePersonRequest) PrimaryEmail() string {
+	if r.Email == "" {
+		return primaryEmail(r.Emails)
+	}
+	return r.Email
+}
+
+func (r *PatchPersonRequest) Validate() error {
+	if r.Name == nil && r.FirstName == nil && r.MiddleName == nil && r.LastName == nil &&
+		r.Email == nil && r.Phone == nil && r.Address == nil && r.DateOfBirth == nil {
+		return errors.New("at least one field must be provided")
+	}
+	if r.Name != nil {
+		if err := validateName(*r.Name); err != nil {
+			return err
+		}
+	}
+	if err := validateNameParts(deref(r.FirstName), deref(r.MiddleName), deref(r.LastName)); err != nil {
+		return err
+	}
+	if r.Email != nil {
+		if err := validateEmailDomain(*r.Email); err != nil {
+			return err
+		}
+	}
+	if r.DateOfBirth != nil {
+		if err := validateDateOfBirth(*r.DateOfBirth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validatePersonFields(name, email string, dateOfBirth Date) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+	if err := validateEmailDomain(email); err != nil {
+		return err
+	}
+	return validateDateOfBirth(dateOfBirth)
+}
+
+// validateNameParts checks the optional first, middle and last names with
+// the same rules as the full name.
+func validateNameParts(first, middle, last string) error {
+	fields := []string{"first_name", "middle_name", "last_name"}
+	for i, part := range []string{first, middle, last} {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		if err := validateName(part); err != nil {
+			return fmt.Errorf("%s: %w", fields[i], err)
+		}
+	}
+	return nil
+}
+
+// FullName joins the non-empty name parts with single spaces.
+func FullName(first, middle, last string) string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{first, middle, last} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// fullNameOr returns the trimmed name, or the full name built from the
+// parts when no name was given.
+func fullNameOr(name, first, middle, last string) string {
+	if name = strings.TrimSpace(name); name != "" {
+		return name
+	}
+	return FullName(first, middle, last)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// validateName checks the name as it will be stored, i.e. with surrounding
+// whitespace trimmed. Length is counted in characters, not bytes.
+func validateName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("name cannot be empty or only whitespace")
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return fmt.Errorf("name cannot exceed %d characters", maxNameLength)
 	}
-	if len(r.Name) > 100 {
-		return errors.New("name cannot exceed 100 characters")
+	if strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return errors.New("name cannot contain control characters such as line breaks")
 	}
-	if r.DateOfBirth.After(time.Now()) {
+	return nil
+}
+
+func validateDateOfBirth(dateOfBirth Date) error {
+	now := time.Now()
+	if dateOfBirth.Time().After(now) {
 		return errors.New("date of birth cannot be in the future")
 	}
+	if dateOfBirth.Time().Year() < minBirthYear {
+		return fmt.Errorf("date of birth cannot be before %d", minBirthYear)
+	}
+	return ageLimits.check(AgeAt(dateOfBirth.Time(), now))
+}
+
+// AgeLimits bounds the age, in completed years, of persons being saved. A
+// zero bound is not enforced.
+type AgeLimits struct {
+	Min int
+	Max int
+}
+
+var ageLimits AgeLimits
+
+// SetAgeLimits sets the bounds enforced by the request Validate methods. Call
+// it once at startup, before serving requests.
+func SetAgeLimits(limits AgeLimits) {
+	ageLimits = limits
+}
+
+func (l AgeLimits) check(age int) error {
+	if l.Min > 0 && age < l.Min {
+		return fmt.Errorf("person must be at least %d years old", l.Min)
+	}
+	if l.Max > 0 && age > l.Max {
+		return fmt.Errorf("person cannot be older than %d years", l.Max)
+	}
 	return nil
 }
 
+// BeforeCreate assigns a time-ordered UUIDv7 to persons created internally
+// without an external ID, which keeps inserts into the external_id index
+// roughly sequential. API requests must supply their own.
 func (p *Person) BeforeCreate(*gorm.DB) error {
 	if p.ExternalID == uuid.Nil {
-		p.ExternalID = uuid.New()
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("generate external id: %w", err)
+		}
+		p.ExternalID = id
 	}
+	p.SearchName = SearchName(p.Name)
 	return nil
 }
 
+// BeforeUpdate refreshes search_name when a map update sets the name, the
+// only way persons are updated.
+func (p *Person) BeforeUpdate(tx *gorm.DB) error {
+	if changes, ok := tx.Statement.Dest.(map[string]interface{}); ok {
+		if name, ok := changes["name"].(string); ok {
+			tx.Statement.SetColumn("search_name", SearchName(name))
+		}
+	}
+	return nil
+}
+
+// SearchName folds a name into the form stored in search_name: trimmed,
+// lowercased and stripped of accents, so "José" becomes "jose". Name
+// filters must fold their input the same way.
+func SearchName(name string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(strings.TrimSpace(name)) {
+		if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+	return norm.NFC.String(strings.ToLower(b.String()))
+}
+
 func (p *Person) ToResponse() PersonResponse {
-	return PersonResponse{
+	resp := PersonResponse{
 		ExternalID:  p.ExternalID,
 		Name:        p.Name,
+		FirstName:   p.FirstName,
+		MiddleName:  p.MiddleName,
+		LastName:    p.LastName,
 		Email:       p.Email,
+		Emails:      []EmailAddress{{Email: p.Email, Primary: true}},
+		Phone:       p.Phone,
 		DateOfBirth: p.DateOfBirth,
+		Active:      p.Active,
+		Age:         AgeAt(p.DateOfBirth.Time(), time.Now()),
+		Version:     p.Version,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+
+		AnonymizedAt: p.AnonymizedAt,
+	}
+	for _, e := range p.Emails {
+		if !e.Primary {
+			resp.Emails = append(resp.Emails, EmailAddress{Email: e.Email})
+		}
+	}
+	if p.Address != (Address{}) {
+		address := p.Address
+		resp.Address = &address
+	}
+	if p.DeletedAt.Valid {
+		deletedAt := p.DeletedAt.Time
+		resp.DeletedAt = &deletedAt
 	}
+	return resp
+}
+
+// AgeAt returns the age in completed years on the given date. Persons born on
+// 29 February have their birthday on 1 March in non-leap years.
+func AgeAt(dateOfBirth, now time.Time) int {
+	birthYear, birthMonth, birthDay := dateOfBirth.Date()
+	year, month, day := now.Date()
+
+	age := year - birthYear
+	if month < birthMonth || (month == birthMonth && day < birthDay) {
+		age--
+	}
+	return age
 }
 
 func FromSaveRequest(req SavePersonRequest) Person {
-	return Person{
+	person := Person{
 		ExternalID:  req.ExternalID,
-		Name:        strings.TrimSpace(req.Name),
-		Email:       req.Email,
+		Name:        fullNameOr(req.Name, req.FirstName, req.MiddleName, req.LastName),
+		FirstName:   strings.TrimSpace(req.FirstName),
+		MiddleName:  strings.TrimSpace(req.MiddleName),
+		LastName:    strings.TrimSpace(req.LastName),
+		Email:       NormalizeEmail(req.PrimaryEmail()),
+		Emails:      secondaryEmails(req.Emails),
+		Phone:       req.Phone,
 		DateOfBirth: req.DateOfBirth,
 	}
+	if req.Address != nil {
+		person.Address = req.Address.normalize()
+	}
+	return person
+}
+
+func (r *UpdatePersonRequest) Changes() map[string]interface{} {
+	changes := map[string]interface{}{
+		"name":          fullNameOr(r.Name, r.FirstName, r.MiddleName, r.LastName),
+		"first_name":    strings.TrimSpace(r.FirstName),
+		"middle_name":   strings.TrimSpace(r.MiddleName),
+		"last_name":     strings.TrimSpace(r.LastName),
+		"email":         NormalizeEmail(r.PrimaryEmail()),
+		"phone":         r.Phone,
+		"date_of_birth": r.DateOfBirth,
+		EmailsChange:    secondaryEmails(r.Emails),
+	}
+	var address Address
+	if r.Address != nil {
+		address = r.Address.normalize()
+	}
+	address.addChanges(changes)
+	return changes
+}
+
+func (a Address) normalize() Address {
+	return Address{
+		Street:     strings.TrimSpace(a.Street),
+		City:       strings.TrimSpace(a.City),
+		Region:     strings.TrimSpace(a.Region),
+		PostalCode: strings.TrimSpace(a.PostalCode),
+		Country:    a.Country,
+	}
+}
+
+// addChanges sets the address_* columns, replacing the whole address.
+func (a Address) addChanges(changes map[string]interface{}) {
+	changes["address_street"] = a.Street
+	changes["address_city"] = a.City
+	changes["address_region"] = a.Region
+	changes["address_postal_code"] = a.PostalCode
+	changes["address_country"] = a.Country
+}
+
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// Changes returns the column updates for the fields present in the request.
+func (r *PatchPersonRequest) Changes() map[string]interface{} {
+	changes := make(map[string]interface{})
+	if r.Name != nil {
+		changes["name"] = strings.TrimSpace(*r.Name)
+	}
+	if r.FirstName != nil {
+		changes["first_name"] = strings.TrimSpace(*r.FirstName)
+	}
+	if r.MiddleName != nil {
+		changes["middle_name"] = strings.TrimSpace(*r.MiddleName)
+	}
+	if r.LastName != nil {
+		changes["last_name"] = strings.TrimSpace(*r.LastName)
+	}
+	if r.Email != nil {
+		changes["email"] = NormalizeEmail(*r.Email)
+	}
+	if r.Phone != nil {
+		changes["phone"] = *r.Phone
+	}
+	if r.Address != nil {
+		r.Address.normalize().addChanges(changes)
+	}
+	if r.DateOfBirth != nil {
+		changes["date_of_birth"] = *r.DateOfBirth
+	}
+	return changes
+}
+
+// DerivedName returns the full name to store when the patch changes name
+// parts but not the name itself, built from the parts as they will be after
+// the patch. It reports false when the name should stay as it is, including
+// when every part ends up empty.
+func (r *PatchPersonRequest) DerivedName(current Person) (string, bool, error) {
+	if r.Name != nil || (r.FirstName == nil && r.MiddleName == nil && r.LastName == nil) {
+		return "", false, nil
+	}
+
+	first, middle, last := current.FirstName, current.MiddleName, current.LastName
+	if r.FirstName != nil {
+		first = *r.FirstName
+	}
+	if r.MiddleName != nil {
+		middle = *r.MiddleName
+	}
+	if r.LastName != nil {
+		last = *r.LastName
+	}
+
+	name := FullName(first, middle, last)
+	if name == "" {
+		return "", false, nil
+	}
+	return name, true, validateName(name)
 }
@@ -0,0 +1,302 @@
+package models
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validSaveRequest() SavePersonRequest {
+	return SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "John Doe",
+		Email:       "john@example.com",
+		DateOfBirth: DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+}
+
+func TestValidateRejectsNilExternalID(t *testing.T) {
+	req := validSaveRequest()
+	req.ExternalID = uuid.Nil
+
+	assert.EqualError(t, req.Validate(), "external_id must not be the nil UUID")
+}
+
+func TestValidateAcceptsValidDateOfBirth(t *testing.T) {
+	req := validSaveRequest()
+
+	assert.NoError(t, req.Validate())
+}
+
+func TestValidateRejectsFutureDateOfBirth(t *testing.T) {
+	req := validSaveRequest()
+	req.DateOfBirth = DateOf(time.Now().AddDate(1, 0, 0))
+
+	err := req.Validate()
+	assert.EqualError(t, err, "date of birth cannot be in the future")
+}
+
+func TestValidateRejectsDateOfBirthBefore1900(t *testing.T) {
+	req := validSaveRequest()
+	req.DateOfBirth = DateOf(time.Date(1899, 12, 31, 0, 0, 0, 0, time.UTC))
+
+	err := req.Validate()
+	assert.EqualError(t, err, "date of birth cannot be before 1900")
+}
+
+func setAgeLimits(t *testing.T, limits AgeLimits) {
+	t.Helper()
+	SetAgeLimits(limits)
+	t.Cleanup(func() { SetAgeLimits(AgeLimits{}) })
+}
+
+func TestValidateIgnoresAgeByDefault(t *testing.T) {
+	req := validSaveRequest()
+	req.DateOfBirth = DateOf(time.Now().AddDate(0, -1, 0))
+
+	assert.NoError(t, req.Validate())
+}
+
+func TestValidateRejectsTooYoungPerson(t *testing.T) {
+	setAgeLimits(t, AgeLimits{Min: 18})
+	req := validSaveRequest()
+	req.DateOfBirth = DateOf(time.Now().AddDate(-18, 0, 1))
+
+	assert.EqualError(t, req.Validate(), "person must be at least 18 years old")
+}
+
+func TestValidateRejectsTooOldPerson(t *testing.T) {
+	setAgeLimits(t, AgeLimits{Max: 100})
+	req := validSaveRequest()
+	req.DateOfBirth = DateOf(time.Now().AddDate(-101, 0, 0))
+
+	assert.EqualError(t, req.Validate(), "person cannot be older than 100 years")
+}
+
+func TestValidateAcceptsAgeWithinLimits(t *testing.T) {
+	setAgeLimits(t, AgeLimits{Min: 18, Max: 100})
+	req := validSaveRequest()
+	req.DateOfBirth = DateOf(time.Now().AddDate(-18, 0, 0))
+
+	assert.NoError(t, req.Validate())
+}
+
+func TestValidateRejectsWhitespaceOnlyName(t *testing.T) {
+	req := validSaveRequest()
+	req.Name = " \t "
+
+	assert.EqualError(t, req.Validate(), "name cannot be empty or only whitespace")
+}
+
+func TestValidateRejectsOverlongName(t *testing.T) {
+	req := validSaveRequest()
+	req.Name = strings.Repeat("a", maxNameLength+1)
+
+	assert.EqualError(t, req.Validate(), "name cannot exceed 100 characters")
+}
+
+func TestValidateCountsNameLengthInCharacters(t *testing.T) {
+	req := validSaveRequest()
+	req.Name = "  " + strings.Repeat("é", maxNameLength) + "  "
+
+	assert.NoError(t, req.Validate())
+}
+
+func TestValidateRejectsControlCharactersInName(t *testing.T) {
+	req := validSaveRequest()
+	req.Name = "John\nDoe"
+
+	assert.EqualError(t, req.Validate(), "name cannot contain control characters such as line breaks")
+}
+
+func TestFromSaveRequestTrimsName(t *testing.T) {
+	req := validSaveRequest()
+	req.Name = "  Jane Doe \n"
+
+	assert.NoError(t, req.Validate())
+	assert.Equal(t, "Jane Doe", FromSaveRequest(req).Name)
+}
+
+func TestFromSaveRequestBuildsNameFromParts(t *testing.T) {
+	req := validSaveRequest()
+	req.Name = ""
+	req.FirstName = " Jane "
+	req.MiddleName = "Q."
+	req.LastName = "Doe"
+
+	require.NoError(t, req.Validate())
+	person := FromSaveRequest(req)
+	assert.Equal(t, "Jane Q. Doe", person.Name)
+	assert.Equal(t, "Jane", person.FirstName)
+	assert.Equal(t, "Q.", person.MiddleName)
+	assert.Equal(t, "Doe", person.LastName)
+}
+
+func TestFromSaveRequestKeepsNameGivenWithParts(t *testing.T) {
+	req := validSaveRequest()
+	req.Name = "Dr. Jane Doe"
+	req.FirstName = "Jane"
+	req.LastName = "Doe"
+
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "Dr. Jane Doe", FromSaveRequest(req).Name)
+}
+
+func TestValidateRejectsOverlongNamePart(t *testing.T) {
+	req := validSaveRequest()
+	req.LastName = strings.Repeat("x", 101)
+
+	assert.EqualError(t, req.Validate(), "last_name: name cannot exceed 100 characters")
+}
+
+func TestFromSaveRequestNormalizesEmail(t *testing.T) {
+	req := validSaveRequest()
+	req.Email = "  John.Doe@Example.COM "
+
+	person := FromSaveRequest(req)
+	assert.Equal(t, "john.doe@example.com", person.Email)
+}
+
+func TestPhoneCarriedThroughToResponse(t *testing.T) {
+	req := validSaveRequest()
+	req.Phone = "+14155552671"
+
+	person := FromSaveRequest(req)
+	assert.Equal(t, "+14155552671", person.ToResponse().Phone)
+}
+
+func TestAddressOmittedFromResponseWhenEmpty(t *testing.T) {
+	person := FromSaveRequest(validSaveRequest())
+	assert.Nil(t, person.ToResponse().Address)
+
+	req := validSaveRequest()
+	req.Address = &Address{City: " Berlin ", Country: "DE"}
+	person = FromSaveRequest(req)
+	assert.Equal(t, &Address{City: "Berlin", Country: "DE"}, person.ToResponse().Address)
+}
+
+func TestPersonListResponseMarshalsToXML(t *testing.T) {
+	req := validSaveRequest()
+	req.Address = &Address{City: "Berlin", Country: "DE"}
+	person := FromSaveRequest(req)
+	response := PersonListResponse{Data: []PersonResponse{person.ToResponse()}, Page: 1, PageSize: 20, Total: 1}
+
+	body, err := xml.Marshal(response)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(string(body), "<persons><person><external_id>"+req.ExternalID.String()+"</external_id>"))
+	assert.Contains(t, string(body), `<emails><email primary="true">john@example.com</email></emails>`)
+	assert.Contains(t, string(body), "<address><city>Berlin</city><country>DE</country></address>")
+	assert.Contains(t, string(body), "<total>1</total></persons>")
+	assert.NotContains(t, string(body), "deleted_at")
+}
+
+func TestPatchChangesReplaceWholeAddress(t *testing.T) {
+	req := PatchPersonRequest{Address: &Address{City: "Paris", Country: "FR"}}
+
+	assert.NoError(t, req.Validate())
+	assert.Equal(t, map[string]interface{}{
+		"address_street":      "",
+		"address_city":        "Paris",
+		"address_region":      "",
+		"address_postal_code": "",
+		"address_country":     "FR",
+	}, req.Changes())
+}
+
+func TestPatchValidateRequiresAField(t *testing.T) {
+	req := PatchPersonRequest{}
+
+	assert.EqualError(t, req.Validate(), "at least one field must be provided")
+}
+
+func TestPatchChangesOnlyIncludesProvidedFields(t *testing.T) {
+	name := "  Jane Doe "
+	req := PatchPersonRequest{Name: &name}
+
+	assert.NoError(t, req.Validate())
+	assert.Equal(t, map[string]interface{}{"name": "Jane Doe"}, req.Changes())
+}
+
+func TestPatchDerivedNameMergesParts(t *testing.T) {
+	last := "Roe"
+	req := PatchPersonRequest{LastName: &last}
+	current := Person{Name: "Jane Doe", FirstName: "Jane", LastName: "Doe"}
+
+	require.NoError(t, req.Validate())
+	name, ok, err := req.DerivedName(current)
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "Jane Roe", name)
+	assert.Equal(t, map[string]interface{}{"last_name": "Roe"}, req.Changes())
+}
+
+func TestPatchDerivedNameKeepsExplicitName(t *testing.T) {
+	name, last := "Jane R.", "Roe"
+	req := PatchPersonRequest{Name: &name, LastName: &last}
+
+	_, ok, err := req.DerivedName(Person{Name: "Jane Doe"})
+
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestAgeAtBirthdayAlreadyPassed(t *testing.T) {
+	dob := time.Date(1990, 3, 15, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 34, AgeAt(dob, now))
+}
+
+func TestAgeAtBirthdayUpcoming(t *testing.T) {
+	dob := time.Date(1990, 9, 15, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 9, 14, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 33, AgeAt(dob, now))
+	assert.Equal(t, 34, AgeAt(dob, now.AddDate(0, 0, 1)))
+}
+
+func TestAgeAtLeapDayBirthday(t *testing.T) {
+	dob := time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 22, AgeAt(dob, time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 23, AgeAt(dob, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 23, AgeAt(dob, time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 24, AgeAt(dob, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestBeforeCreateGeneratesTimeOrderedUUIDv7(t *testing.T) {
+	first, second := &Person{}, &Person{}
+
+	assert.NoError(t, first.BeforeCreate(nil))
+	assert.NoError(t, second.BeforeCreate(nil))
+
+	assert.Equal(t, uuid.Version(7), first.ExternalID.Version())
+	assert.Equal(t, uuid.Version(7), second.ExternalID.Version())
+	assert.Less(t, first.ExternalID.String(), second.ExternalID.String())
+}
+
+func TestBeforeCreateKeepsClientSuppliedID(t *testing.T) {
+	supplied := uuid.New()
+	person := &Person{ExternalID: supplied}
+
+	assert.NoError(t, person.BeforeCreate(nil))
+	assert.Equal(t, supplied, person.ExternalID)
+}
+
+func TestSearchNameFoldsCaseAndAccents(t *testing.T) {
+	for name, want := range map[string]string{
+		"José":               "jose",
+		"  ÅNGSTRÖM Müller ": "angstrom muller",
+		"Zoë O'Brien":        "zoe o'brien",
+		"jose":               "jose",
+	} {
+		assert.Equal(t, want, SearchName(name), name)
+	}
+}
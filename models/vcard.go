@@ -0,0 +1,83 @@
+package models
+
+import (
+	"strings"
+)
+
+// vcardLineLength is the longest content line, in octets, before it is
+// folded (RFC 6350 section 3.2).
+const vcardLineLength = 75
+
+// VCard renders the person as a vCard 4.0 document (RFC 6350) with CRLF line
+// endings. N, TEL and ADR are only included when the person has them.
+func (p Person) VCard() string {
+	var b strings.Builder
+	writeLine := func(line string) {
+		b.WriteString(foldVCardLine(line))
+		b.WriteString("\r\n")
+	}
+
+	writeLine("BEGIN:VCARD")
+	writeLine("VERSION:4.0")
+	writeLine("UID:urn:uuid:" + p.ExternalID.String())
+	writeLine("FN:" + escapeVCardText(p.Name))
+	if p.FirstName != "" || p.MiddleName != "" || p.LastName != "" {
+		// N components: family; given; additional; prefixes; suffixes.
+		writeLine("N:" + strings.Join([]string{
+			escapeVCardText(p.LastName),
+			escapeVCardText(p.FirstName),
+			escapeVCardText(p.MiddleName),
+			"",
+			"",
+		}, ";"))
+	}
+	writeLine("EMAIL:" + escapeVCardText(p.Email))
+	if p.Phone != "" {
+		writeLine("TEL;VALUE=uri:tel:" + p.Phone)
+	}
+	writeLine("BDAY:" + p.DateOfBirth.Time().Format("20060102"))
+	if p.Address != (Address{}) {
+		// ADR components: PO box; extended address; street; locality;
+		// region; postal code; country.
+		writeLine("ADR:;;" + strings.Join([]string{
+			escapeVCardText(p.Address.Street),
+			escapeVCardText(p.Address.City),
+			escapeVCardText(p.Address.Region),
+			escapeVCardText(p.Address.PostalCode),
+			escapeVCardText(p.Address.Country),
+		}, ";"))
+	}
+	writeLine("END:VCARD")
+	return b.String()
+}
+
+var vcardTextEscaper = strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\n", `\n`)
+
+func escapeVCardText(value string) string {
+	return vcardTextEscaper.Replace(value)
+}
+
+// foldVCardLine splits line into chunks of at most vcardLineLength octets,
+// continuing each with CRLF and a space and never splitting a UTF-8
+// sequence.
+func foldVCardLine(line string) string {
+	var b strings.Builder
+	limit := vcardLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isUTF8Start(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space counts towards the continuation line.
+		limit = vcardLineLength - 1
+	}
+	b.WriteString(line)
+	return b.String()
+}
+
+func isUTF8Start(c byte) bool {
+	return c&0xC0 != 0x80
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonVCard(t *testing.T) {
+	person := Person{
+		ExternalID:  uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+		Name:        "Doe, Jane",
+		Email:       "jane@example.com",
+		Phone:       "+14155552671",
+		Address:     Address{Street: "1 Main St", City: "Springfield", PostalCode: "12345", Country: "US"},
+		DateOfBirth: DateOf(time.Date(1990, 4, 2, 0, 0, 0, 0, time.UTC)),
+	}
+
+	assert.Equal(t, "BEGIN:VCARD\r\n"+
+		"VERSION:4.0\r\n"+
+		"UID:urn:uuid:550e8400-e29b-41d4-a716-446655440000\r\n"+
+		"FN:Doe\\, Jane\r\n"+
+		"EMAIL:jane@example.com\r\n"+
+		"TEL;VALUE=uri:tel:+14155552671\r\n"+
+		"BDAY:19900402\r\n"+
+		"ADR:;;1 Main St;Springfield;;12345;US\r\n"+
+		"END:VCARD\r\n", person.VCard())
+}
+
+func TestPersonVCardOmitsMissingPhoneAndAddress(t *testing.T) {
+	person := Person{Name: "Jane Doe", Email: "jane@example.com", DateOfBirth: DateOf(time.Date(1990, 4, 2, 0, 0, 0, 0, time.UTC))}
+
+	card := person.VCard()
+
+	assert.NotContains(t, card, "TEL")
+	assert.NotContains(t, card, "ADR")
+	assert.NotContains(t, card, "\r\nN:")
+}
+
+func TestPersonVCardIncludesNameParts(t *testing.T) {
+	person := Person{Name: "Jane Q. Doe", FirstName: "Jane", MiddleName: "Q.", LastName: "Doe", DateOfBirth: DateOf(time.Date(1990, 4, 2, 0, 0, 0, 0, time.UTC))}
+
+	assert.Contains(t, person.VCard(), "\r\nN:Doe;Jane;Q.;;\r\n")
+}
+
+func TestFoldVCardLine(t *testing.T) {
+	line := "FN:" + strings.Repeat("ä", 50)
+
+	folded := foldVCardLine(line)
+
+	for _, part := range strings.Split(folded, "\r\n") {
+		assert.LessOrEqual(t, len(part), vcardLineLength)
+	}
+	assert.Equal(t, line, strings.ReplaceAll(folded, "\r\n ", ""))
+}
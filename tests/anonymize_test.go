@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"person-service/database"
+	"person-service/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnonymizePerson(t *testing.T) {
+	cleanTestData()
+	person := seedPersons(t, "Test Anonymize")[0]
+	// The anonymized name no longer matches cleanTestData.
+	t.Cleanup(func() { db.Unscoped().Delete(&models.Person{}, person.ID) })
+	require.NoError(t, db.Model(&person).Updates(map[string]interface{}{
+		"phone":        "+4915112345678",
+		"address_city": "Berlin",
+	}).Error)
+	require.NoError(t, database.RecordAudit(db, models.AuditUpdate, &person, &person))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", fmt.Sprintf("/v1/persons/%d/anonymize", person.ID), nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response models.PersonResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, models.RedactedName, response.Name)
+	assert.Equal(t, models.AnonymizedEmail(person.ExternalID), response.Email)
+	assert.Equal(t, models.AnonymizedDateOfBirth, response.DateOfBirth)
+	assert.Empty(t, response.Phone)
+	assert.Nil(t, response.Address)
+	assert.NotNil(t, response.AnonymizedAt)
+	assert.Equal(t, person.ExternalID, response.ExternalID)
+
+	for _, path := range []string{fmt.Sprintf("/v1/%d", person.ID), "/v1/persons/by-external/" + person.ExternalID.String()} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		require.Equal(t, http.StatusOK, w.Code, path)
+
+		var fetched models.PersonResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &fetched))
+		assert.Equal(t, models.RedactedName, fetched.Name, path)
+		assert.NotContains(t, w.Body.String(), "testseed0@example.com", path)
+	}
+
+	var stored models.Person
+	require.NoError(t, db.First(&stored, person.ID).Error)
+	assert.Equal(t, "redacted", stored.SearchName)
+
+	history := getHistory(t, person.ID)
+	require.Len(t, history, 2)
+	assert.Equal(t, models.AuditAnonymize, history[len(history)-1].Action)
+	for _, entry := range history {
+		assert.NotContains(t, string(entry.Before), "Test Anonymize")
+		assert.NotContains(t, string(entry.After), "Test Anonymize")
+	}
+}
+
+func TestAnonymizePersonNotFound(t *testing.T) {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/v1/persons/999999/anonymize", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getHistory(t *testing.T, id uint) []models.AuditEntry {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/v1/persons/%d/history", id), nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response models.HistoryResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response.Data
+}
+
+func TestPersonHistoryRecordsCreateAndUpdate(t *testing.T) {
+	cleanTestData()
+
+	saveReq := models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test History",
+		Email:       "testhistory@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+	w := postSaveWithIdempotencyKey(t, saveReq, "")
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var person models.Person
+	require.NoError(t, db.Where("external_id = ?", saveReq.ExternalID).First(&person).Error)
+
+	body, err := json.Marshal(models.UpdatePersonRequest{
+		Name:        "Test History Renamed",
+		Email:       saveReq.Email,
+		DateOfBirth: saveReq.DateOfBirth,
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/v1/%d", person.ID), bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	history := getHistory(t, person.ID)
+	require.Len(t, history, 2)
+
+	assert.Equal(t, models.AuditCreate, history[0].Action)
+	assert.Equal(t, person.ID, history[0].PersonID)
+	assert.JSONEq(t, "null", string(history[0].Before))
+	var created models.Person
+	require.NoError(t, json.Unmarshal(history[0].After, &created))
+	assert.Equal(t, "Test History", created.Name)
+
+	assert.Equal(t, models.AuditUpdate, history[1].Action)
+	var before, after models.Person
+	require.NoError(t, json.Unmarshal(history[1].Before, &before))
+	require.NoError(t, json.Unmarshal(history[1].After, &after))
+	assert.Equal(t, "Test History", before.Name)
+	assert.Equal(t, "Test History Renamed", after.Name)
+	assert.Equal(t, before.Version+1, after.Version)
+	assert.False(t, history[1].CreatedAt.Before(history[0].CreatedAt))
+}
+
+func TestPersonHistoryKeepsDeletes(t *testing.T) {
+	cleanTestData()
+	person := seedPersons(t, "Test History Delete")[0]
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", fmt.Sprintf("/v1/%d", person.ID), nil))
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	history := getHistory(t, person.ID)
+	require.Len(t, history, 1)
+	assert.Equal(t, models.AuditDelete, history[0].Action)
+	assert.JSONEq(t, "null", string(history[0].After))
+}
+
+func TestPersonHistoryNotFound(t *testing.T) {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/v1/persons/999999/history", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
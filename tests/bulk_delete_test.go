@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeletePersonsByEmailDomain(t *testing.T) {
+	cleanTestData()
+	kept := seedPersonWithEmail(t, "Test Bulk Kept", "kept@globex.example")
+	one := seedPersonWithEmail(t, "Test Bulk One", "one@acme.example")
+	two := seedPersonWithEmail(t, "Test Bulk Two", "two@acme.example")
+
+	req := httptest.NewRequest("DELETE", "/v1/persons?email_domain=acme.example&confirm=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response models.BulkDeleteResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Deleted)
+
+	var remaining []models.Person
+	require.NoError(t, db.Where("name LIKE ?", "Test Bulk%").Find(&remaining).Error)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, kept.ID, remaining[0].ID)
+
+	ids := []uint{one.ID, two.ID}
+	var deleted, audits int64
+	require.NoError(t, db.Unscoped().Model(&models.Person{}).Where("id IN ? AND deleted_at IS NOT NULL", ids).Count(&deleted).Error)
+	assert.Equal(t, int64(2), deleted, "bulk delete is soft")
+	require.NoError(t, db.Model(&models.AuditEntry{}).Where("person_id IN ? AND action = ?", ids, models.AuditDelete).Count(&audits).Error)
+	assert.Equal(t, int64(2), audits)
+}
+
+func TestDeletePersonsWithoutConfirmDeletesNothing(t *testing.T) {
+	cleanTestData()
+	seedPersonWithEmail(t, "Test Bulk Unconfirmed", "unconfirmed@acme.example")
+
+	req := httptest.NewRequest("DELETE", "/v1/persons?email_domain=acme.example", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var count int64
+	require.NoError(t, db.Model(&models.Person{}).Where("name = ?", "Test Bulk Unconfirmed").Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestDeletePersonsWithOnlyIncludeInactiveDeletesNothing(t *testing.T) {
+	cleanTestData()
+	seedPersonWithEmail(t, "Test Bulk Unfiltered", "unfiltered@acme.example")
+
+	req := httptest.NewRequest("DELETE", "/v1/persons?confirm=true&include_inactive=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var count int64
+	require.NoError(t, db.Model(&models.Person{}).Where("name = ?", "Test Bulk Unfiltered").Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"person-service/cache"
+	"person-service/handlers"
+	"person-service/middleware"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cachedRouter() *gin.Engine {
+	r := gin.New()
+	h := handlers.NewPersonHandler(db, handlers.WithCache(cache.NewLRU(10, time.Minute)))
+	handlers.RegisterRoutes(r.Group("/v1", defaultTestTenant, middleware.Tenant()), h)
+	return r
+}
+
+func getPersonName(t *testing.T, r *gin.Engine, id uint) string {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/v1/%d", id), nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response models.PersonResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response.Name
+}
+
+func TestGetPersonServedFromCache(t *testing.T) {
+	cleanTestData()
+	r := cachedRouter()
+	person := seedPersons(t, "Test Cached")[0]
+
+	assert.Equal(t, "Test Cached", getPersonName(t, r, person.ID))
+
+	// Change the row behind the handler's back; the cached copy still wins.
+	require.NoError(t, db.Model(&person).Update("name", "Test Changed Directly").Error)
+
+	assert.Equal(t, "Test Cached", getPersonName(t, r, person.ID))
+}
+
+func TestUpdatePersonInvalidatesCache(t *testing.T) {
+	cleanTestData()
+	r := cachedRouter()
+	person := seedPersons(t, "Test Before Update")[0]
+
+	assert.Equal(t, "Test Before Update", getPersonName(t, r, person.ID))
+
+	body, err := json.Marshal(models.UpdatePersonRequest{
+		Name:        "Test After Update",
+		Email:       person.Email,
+		DateOfBirth: person.DateOfBirth,
+	})
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/v1/%d", person.ID), bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	assert.Equal(t, "Test After Update", getPersonName(t, r, person.ID))
+}
+
+func TestDeletePersonInvalidatesCache(t *testing.T) {
+	cleanTestData()
+	r := cachedRouter()
+	person := seedPersons(t, "Test Cached Delete")[0]
+
+	getPersonName(t, r, person.ID)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("DELETE", fmt.Sprintf("/v1/%d", person.ID), nil))
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/v1/%d", person.ID), nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavePersonAcceptsDateOnlyAndTimestampDateOfBirth(t *testing.T) {
+	cleanTestData()
+
+	tests := []struct {
+		name        string
+		dateOfBirth string
+	}{
+		{"date only", "1990-04-02"},
+		{"timestamp", "1990-04-02T12:00:00Z"},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			externalID := uuid.New()
+			jsonBody, err := json.Marshal(map[string]string{
+				"external_id":   externalID.String(),
+				"name":          "Test Date Only",
+				"email":         fmt.Sprintf("testdateonly%d@example.com", i),
+				"date_of_birth": tt.dateOfBirth,
+			})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest("POST", "/v1/save", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "1990-04-02", response["date_of_birth"])
+
+			var stored models.Person
+			require.NoError(t, db.Where("external_id = ?", externalID).First(&stored).Error)
+			assert.Equal(t, models.NewDate(1990, time.April, 2), stored.DateOfBirth)
+		})
+	}
+}
+
+func TestGetPersonRendersDateOfBirthAsDateOnly(t *testing.T) {
+	cleanTestData()
+	person := seedPersons(t, "Test Date Render")[0]
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/v1/"+person.ExternalID.String(), nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	assert.Contains(t, w.Body.String(), `"date_of_birth":"1980-01-01"`)
+}
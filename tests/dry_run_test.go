@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavePersonDryRunDoesNotCreate(t *testing.T) {
+	cleanTestData()
+
+	reqBody := models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Dry Run",
+		Email:       "TestDryRun@Example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+
+	code, response := postSave(t, "/v1/save?dry_run=true", reqBody)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, reqBody.ExternalID, response.ExternalID)
+	assert.Equal(t, "testdryrun@example.com", response.Email)
+
+	var count int64
+	require.NoError(t, db.Model(&models.Person{}).Where("external_id = ?", reqBody.ExternalID).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestSavePersonDryRunInvalid(t *testing.T) {
+	cleanTestData()
+
+	jsonBody, err := json.Marshal(models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Dry Run Invalid",
+		Email:       "invalid-email",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/v1/save?dry_run=true", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var response models.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.NotEmpty(t, response.Error)
+}
+
+func TestSavePersonDryRunReportsConflict(t *testing.T) {
+	cleanTestData()
+	existing := seedPersons(t, "Test Dry Run Existing")[0]
+
+	code, _ := postSave(t, "/v1/save?dry_run=true", models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Dry Run Conflict",
+		Email:       existing.Email,
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	assert.Equal(t, http.StatusConflict, code)
+
+	code, _ = postSave(t, "/v1/save?dry_run=true", models.SavePersonRequest{
+		ExternalID:  existing.ExternalID,
+		Name:        "Test Dry Run Conflict",
+		Email:       "testdryrunconflict@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	assert.Equal(t, http.StatusConflict, code)
+}
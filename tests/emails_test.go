@@ -0,0 +1,232 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavePersonWithMultipleEmails(t *testing.T) {
+	cleanTestData()
+
+	body, err := json.Marshal(models.SavePersonRequest{
+		ExternalID: uuid.New(),
+		Name:       "Test Multiple Emails",
+		Emails: []models.EmailAddress{
+			{Email: "testhome@example.com", Primary: true},
+			{Email: "testwork@example.com"},
+		},
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest("POST", "/save", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var created models.PersonResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "testhome@example.com", created.Email)
+	want := []models.EmailAddress{
+		{Email: "testhome@example.com", Primary: true},
+		{Email: "testwork@example.com"},
+	}
+	assert.Equal(t, want, created.Emails)
+
+	var person models.Person
+	require.NoError(t, db.Where("external_id = ?", created.ExternalID).First(&person).Error)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/v1/%d", person.ID), nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var fetched models.PersonResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &fetched))
+	assert.Equal(t, want, fetched.Emails)
+
+	// Promoting the work address leaves a single row for it, the primary.
+	req = httptest.NewRequest("PATCH", fmt.Sprintf("/v1/%d", person.ID), strings.NewReader(`{"email": "testwork@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var patched models.PersonResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &patched))
+	assert.Equal(t, []models.EmailAddress{{Email: "testwork@example.com", Primary: true}}, patched.Emails)
+
+	var rows []models.PersonEmail
+	require.NoError(t, db.Where("person_id = ?", person.ID).Find(&rows).Error)
+	require.Len(t, rows, 1)
+	assert.True(t, rows[0].Primary)
+}
+
+func TestSavePersonRejectsTwoPrimaryEmails(t *testing.T) {
+	cleanTestData()
+
+	body := fmt.Sprintf(`{
+		"external_id": %q,
+		"name": "Test Two Primaries",
+		"emails": [{"email": "testa@example.com", "primary": true}, {"email": "testb@example.com", "primary": true}],
+		"date_of_birth": "1990-01-01"
+	}`, uuid.New())
+	req := httptest.NewRequest("POST", "/save", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "exactly one primary")
+}
+
+func TestPersonEmailsAllowOnePrimaryPerPerson(t *testing.T) {
+	cleanTestData()
+	person := seedPersons(t, "Test Primary Constraint")[0]
+
+	var rows []models.PersonEmail
+	require.NoError(t, db.Where("person_id = ?", person.ID).Find(&rows).Error)
+	require.Len(t, rows, 1)
+	assert.Equal(t, person.Email, rows[0].Email)
+	assert.True(t, rows[0].Primary)
+
+	err := db.Create(&models.PersonEmail{PersonID: person.ID, Email: "testsecond@example.com", Primary: true}).Error
+	assert.ErrorContains(t, err, "idx_person_emails_primary")
+}
+
+func TestSavePersonUpsertReplacesEmails(t *testing.T) {
+	cleanTestData()
+
+	reqBody := models.SavePersonRequest{
+		ExternalID: uuid.New(),
+		Name:       "Test Upsert Emails",
+		Emails: []models.EmailAddress{
+			{Email: "testhome@example.com", Primary: true},
+			{Email: "testold@example.com"},
+			{Email: "testkept@example.com"},
+		},
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	code, _ := postSave(t, "/v1/save?upsert=true", reqBody)
+	require.Equal(t, http.StatusCreated, code)
+
+	reqBody.Emails = []models.EmailAddress{
+		{Email: "testhome@example.com", Primary: true},
+		{Email: "testkept@example.com"},
+		{Email: "testnew@example.com"},
+	}
+	code, response := postSave(t, "/v1/save?upsert=true", reqBody)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, reqBody.Emails, response.Emails)
+
+	var emails []string
+	require.NoError(t, db.Model(&models.PersonEmail{}).
+		Joins("JOIN people ON people.id = person_emails.person_id").
+		Where("people.external_id = ? AND NOT is_primary", reqBody.ExternalID).
+		Order("person_emails.email").Pluck("person_emails.email", &emails).Error)
+	assert.Equal(t, []string{"testkept@example.com", "testnew@example.com"}, emails)
+}
+
+func TestSavePersonRejectsAnotherPersonsAddress(t *testing.T) {
+	cleanTestData()
+
+	code, _ := postSave(t, "/v1/save", models.SavePersonRequest{
+		ExternalID: uuid.New(),
+		Name:       "Test Address Owner",
+		Emails: []models.EmailAddress{
+			{Email: "testowner@example.com", Primary: true},
+			{Email: "testshared@example.com"},
+		},
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	tests := []struct {
+		name   string
+		emails []models.EmailAddress
+	}{
+		{"primary is their other address", []models.EmailAddress{{Email: "testshared@example.com", Primary: true}}},
+		{"other address is their primary", []models.EmailAddress{{Email: "testfree@example.com", Primary: true}, {Email: "testowner@example.com"}}},
+		{"other address is their other address", []models.EmailAddress{{Email: "testfree@example.com", Primary: true}, {Email: "testshared@example.com"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqBody := models.SavePersonRequest{
+				ExternalID:  uuid.New(),
+				Name:        "Test Address Taker",
+				Emails:      tt.emails,
+				DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+			}
+			for _, path := range []string{"/v1/save", "/v1/save?dry_run=true", "/v1/save?upsert=true"} {
+				code, _ := postSave(t, path, reqBody)
+				assert.Equal(t, http.StatusConflict, code, path)
+			}
+
+			body, err := json.Marshal([]models.SavePersonRequest{reqBody})
+			require.NoError(t, err)
+			req := httptest.NewRequest("POST", "/v1/save/batch", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusMultiStatus, w.Code, w.Body.String())
+			var batch models.BatchSaveResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &batch))
+			assert.Equal(t, http.StatusConflict, batch.Results[0].Status)
+		})
+	}
+
+	var count int64
+	require.NoError(t, db.Model(&models.Person{}).Where("name = ?", "Test Address Taker").Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestUpdatePersonReplacesEmails(t *testing.T) {
+	cleanTestData()
+	person := seedPersons(t, "Test Update Emails")[0]
+
+	update := func(reqBody models.UpdatePersonRequest) models.PersonResponse {
+		t.Helper()
+		body, err := json.Marshal(reqBody)
+		require.NoError(t, err)
+		req := httptest.NewRequest("PUT", fmt.Sprintf("/v1/%d", person.ID), bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response models.PersonResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	emails := []models.EmailAddress{
+		{Email: "testprimary@example.com", Primary: true},
+		{Email: "testother@example.com"},
+	}
+	response := update(models.UpdatePersonRequest{
+		Name:        "Test Update Emails",
+		Emails:      emails,
+		DateOfBirth: person.DateOfBirth,
+	})
+	assert.Equal(t, "testprimary@example.com", response.Email)
+	assert.Equal(t, emails, response.Emails)
+
+	// Like the name parts, the other addresses are cleared when left out.
+	response = update(models.UpdatePersonRequest{
+		Name:        "Test Update Emails",
+		Email:       "testprimary@example.com",
+		DateOfBirth: person.DateOfBirth,
+	})
+	assert.Equal(t, []models.EmailAddress{{Email: "testprimary@example.com", Primary: true}}, response.Emails)
+
+	var rows []models.PersonEmail
+	require.NoError(t, db.Where("person_id = ?", person.ID).Find(&rows).Error)
+	require.Len(t, rows, 1)
+	assert.True(t, rows[0].Primary)
+}
@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/events"
+	"person-service/handlers"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	kafkaContainer "github.com/testcontainers/testcontainers-go/modules/kafka"
+)
+
+func TestKafkaPublisherProducesCreatedEvent(t *testing.T) {
+	cleanTestData()
+
+	broker, err := kafkaContainer.RunContainer(ctx,
+		kafkaContainer.WithClusterID("person-service-test"),
+		testcontainers.WithImage("confluentinc/confluent-local:7.5.0"),
+	)
+	require.NoError(t, err)
+	defer func() {
+		if err := broker.Terminate(ctx); err != nil {
+			t.Logf("Failed to terminate kafka container: %v", err)
+		}
+	}()
+
+	brokers, err := broker.Brokers(ctx)
+	require.NoError(t, err)
+
+	const topic = "person-events-test"
+	publisher := events.NewKafkaPublisher(brokers, topic)
+	defer publisher.Close()
+
+	kafkaRouter := gin.New()
+	kafkaRouter.POST("/save", handlers.NewPersonHandler(db, handlers.WithPublisher(publisher)).SavePerson)
+
+	reqBody := models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Kafka Event",
+		Email:       "testkafka@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/save", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	kafkaRouter.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     brokers,
+		Topic:       topic,
+		StartOffset: kafka.FirstOffset,
+		MaxWait:     time.Second,
+	})
+	defer reader.Close()
+
+	readCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	message, err := reader.ReadMessage(readCtx)
+	require.NoError(t, err)
+	assert.Equal(t, reqBody.ExternalID.String(), string(message.Key))
+
+	var person models.Person
+	require.NoError(t, json.Unmarshal(message.Value, &person))
+	assert.Equal(t, reqBody.ExternalID, person.ExternalID)
+	assert.Equal(t, "Test Kafka Event", person.Name)
+
+	// With the topic in place a publish must not wait out kafka-go's default
+	// one-second batch timeout.
+	start := time.Now()
+	require.NoError(t, publisher.PublishCreated(ctx, person))
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
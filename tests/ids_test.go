@@ -0,0 +1,47 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPersonsByIDsKeepsRequestedOrder(t *testing.T) {
+	cleanTestData()
+	persons := seedPersons(t, "Test IDs A", "Test IDs B", "Test IDs C")
+	missingID := persons[2].ID + 1000
+
+	query := fmt.Sprintf("ids=%d,%d,%d,%d", persons[2].ID, missingID, persons[0].ID, persons[2].ID)
+	req := httptest.NewRequest("GET", "/v1/persons?"+query, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response models.PersonsByIDResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Data, 2)
+	assert.Equal(t, persons[2].ExternalID, response.Data[0].ExternalID)
+	assert.Equal(t, persons[0].ExternalID, response.Data[1].ExternalID)
+	assert.Equal(t, []uint{missingID}, response.Missing)
+}
+
+func TestListPersonsByIDsRejectsInvalidList(t *testing.T) {
+	tooMany := make([]string, 101)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprint(i + 1)
+	}
+
+	for _, ids := range []string{"1,abc", "1,,2", "0", strings.Join(tooMany, ",")} {
+		req := httptest.NewRequest("GET", "/v1/persons?ids="+ids, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, ids)
+	}
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"fmt"
+	"person-service/database"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListWindowTotalMatchesSeparateCount(t *testing.T) {
+	cleanTestData()
+	seedPersons(t, "Test Total A", "Test Total B", "Test Total C", "Test Total D", "Test Total E")
+	persons := database.NewPersonRepository(db, true)
+	tenantCtx := database.WithTenant(ctx, testTenantID)
+
+	for _, opts := range []database.ListOptions{
+		{Filter: database.PersonFilter{Name: "test total"}, Order: "id asc", Limit: 2},
+		{Filter: database.PersonFilter{Name: "test total"}, Order: "id asc", Limit: 2, Offset: 4},
+		// A page past the last one has no rows to carry the total.
+		{Filter: database.PersonFilter{Name: "test total"}, Order: "id asc", Limit: 2, Offset: 10},
+		{Filter: database.PersonFilter{Name: "no such person"}, Order: "id asc", Limit: 2},
+	} {
+		counted, countedTotal, err := persons.List(tenantCtx, opts)
+		require.NoError(t, err)
+
+		opts.WindowTotal = true
+		windowed, windowTotal, err := persons.List(tenantCtx, opts)
+		require.NoError(t, err)
+
+		assert.Equal(t, countedTotal, windowTotal, "offset %d", opts.Offset)
+		assert.Equal(t, len(counted), len(windowed), "offset %d", opts.Offset)
+		for i := range counted {
+			assert.Equal(t, counted[i].ID, windowed[i].ID)
+			assert.Equal(t, counted[i].Email, windowed[i].Email)
+		}
+	}
+}
+
+// BenchmarkListTotal compares counting the matches in a separate query with
+// COUNT(*) OVER() in the page query, on a table of benchmarkListRows
+// persons.
+func BenchmarkListTotal(b *testing.B) {
+	const benchmarkListRows = 20000
+	cleanTestData()
+	b.Cleanup(cleanTestData)
+
+	rows := make([]models.Person, benchmarkListRows)
+	for i := range rows {
+		rows[i] = models.Person{
+			TenantID:    testTenantID,
+			ExternalID:  uuid.New(),
+			Name:        fmt.Sprintf("Test Bench %d", i),
+			Email:       fmt.Sprintf("testbench%d@example.com", i),
+			DateOfBirth: models.DateOf(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)),
+		}
+	}
+	require.NoError(b, db.CreateInBatches(&rows, 1000).Error)
+
+	persons := database.NewPersonRepository(db, true)
+	tenantCtx := database.WithTenant(ctx, testTenantID)
+	for _, window := range []bool{false, true} {
+		b.Run(fmt.Sprintf("window=%t", window), func(b *testing.B) {
+			opts := database.ListOptions{Order: "id asc", Limit: 20, Offset: 100, WindowTotal: window}
+			for i := 0; i < b.N; i++ {
+				if _, _, err := persons.List(tenantCtx, opts); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
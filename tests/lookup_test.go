@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lookupPersons(t *testing.T, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	jsonBody, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/v1/persons/lookup", bytes.NewReader(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestLookupPersonsReturnsFoundAndMissing(t *testing.T) {
+	cleanTestData()
+	persons := seedPersons(t, "Test Lookup A", "Test Lookup B", "Test Lookup C")
+	missing := uuid.New()
+
+	w := lookupPersons(t, models.LookupRequest{ExternalIDs: []uuid.UUID{
+		persons[2].ExternalID, missing, persons[0].ExternalID, persons[2].ExternalID,
+	}})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response models.LookupResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Data, 2)
+	assert.Equal(t, persons[2].ExternalID, response.Data[0].ExternalID)
+	assert.Equal(t, persons[0].ExternalID, response.Data[1].ExternalID)
+	assert.Equal(t, []uuid.UUID{missing}, response.Missing)
+}
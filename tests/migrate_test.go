@@ -0,0 +1,163 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/database"
+	"person-service/handlers"
+	"person-service/models"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func startFreshDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	pg, fresh, err := startPostgres(ctx)
+	t.Cleanup(func() {
+		if pg != nil {
+			_ = pg.Terminate(ctx)
+		}
+	})
+	require.NoError(t, err)
+	return fresh
+}
+
+func TestMigrateCreatesSchema(t *testing.T) {
+	fresh := startFreshDatabase(t)
+
+	require.NoError(t, database.Migrate(fresh))
+
+	migrator := fresh.Migrator()
+	require.True(t, migrator.HasTable(&models.Person{}))
+	for _, column := range []string{
+		"id", "tenant_id", "external_id", "name", "first_name", "middle_name", "last_name", "search_name", "email", "phone", "active",
+		"address_street", "address_city", "address_region", "address_postal_code", "address_country",
+		"date_of_birth", "created_at", "updated_at", "deleted_at", "anonymized_at", "idempotency_key", "version",
+	} {
+		assert.True(t, migrator.HasColumn(&models.Person{}, column), "missing column %s", column)
+	}
+	for _, index := range []string{"idx_people_deleted_at", "idx_people_search", "idx_people_email_domain", models.ExternalIDIndex, models.EmailIndex, models.IdempotencyKeyIndex} {
+		assert.True(t, migrator.HasIndex(&models.Person{}, index), "missing index %s", index)
+	}
+
+	var version int
+	require.NoError(t, fresh.Raw("SELECT version FROM schema_migrations").Scan(&version).Error)
+	assert.Equal(t, 12, version)
+	var triggers int64
+	require.NoError(t, fresh.Raw("SELECT count(*) FROM pg_trigger WHERE tgname = 'people_notify_change'").Scan(&triggers).Error)
+	assert.Equal(t, int64(1), triggers)
+	assert.True(t, migrator.HasTable(&models.AuditEntry{}))
+	assert.True(t, migrator.HasTable(&models.PersonEmail{}))
+	assert.True(t, migrator.HasIndex(&models.PersonEmail{}, "idx_person_emails_primary"))
+
+	// Running again with nothing pending is a no-op.
+	assert.NoError(t, database.Migrate(fresh))
+
+	// The pool stays usable after migrating.
+	sqlDB, err := fresh.DB()
+	require.NoError(t, err)
+	assert.NoError(t, sqlDB.Ping())
+}
+
+func TestConcurrentMigrationsWaitForEachOther(t *testing.T) {
+	fresh := startFreshDatabase(t)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = database.Migrate(fresh)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, database.CheckMigrated(context.Background(), fresh))
+
+	// Both runs released the lock on their pooled connections.
+	var advisoryLocks int64
+	require.NoError(t, fresh.Raw("SELECT count(*) FROM pg_locks WHERE locktype = 'advisory'").Scan(&advisoryLocks).Error)
+	assert.Zero(t, advisoryLocks)
+}
+
+func TestBackfillSearchNamesFillsExistingRows(t *testing.T) {
+	fresh := startFreshDatabase(t)
+	require.NoError(t, database.Migrate(fresh))
+
+	person := models.Person{
+		TenantID:    uuid.New(),
+		Name:        "José Müller",
+		Email:       "jose@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	require.NoError(t, fresh.Create(&person).Error)
+	// Rows stored before the migration have the column's empty default.
+	require.NoError(t, fresh.Exec("UPDATE people SET search_name = ''").Error)
+
+	filled, err := database.BackfillSearchNames(context.Background(), fresh)
+	require.NoError(t, err)
+	assert.Equal(t, 1, filled)
+
+	var searchName string
+	require.NoError(t, fresh.Raw("SELECT search_name FROM people WHERE id = ?", person.ID).Scan(&searchName).Error)
+	assert.Equal(t, "jose muller", searchName)
+
+	filled, err = database.BackfillSearchNames(context.Background(), fresh)
+	require.NoError(t, err)
+	assert.Zero(t, filled)
+}
+
+func TestReadyzWaitsForMigrations(t *testing.T) {
+	fresh := startFreshDatabase(t)
+	healthRouter := gin.New()
+	healthRouter.GET("/readyz", handlers.NewHealthHandler(fresh).Ready)
+	ready := func() (int, string) {
+		w := httptest.NewRecorder()
+		healthRouter.ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+		var response map[string]string
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response["status"]
+	}
+
+	code, status := ready()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "migrating", status)
+
+	require.NoError(t, database.Migrate(fresh))
+	code, status = ready()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", status)
+
+	// A migration that failed halfway leaves the version dirty.
+	require.NoError(t, fresh.Exec("UPDATE schema_migrations SET dirty = true").Error)
+	code, status = ready()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "migrating", status)
+}
+
+func TestMigrateDownDropsSchema(t *testing.T) {
+	fresh := startFreshDatabase(t)
+
+	require.NoError(t, database.Migrate(fresh))
+	require.NoError(t, database.MigrateDown(fresh))
+
+	assert.False(t, fresh.Migrator().HasTable(&models.Person{}))
+	assert.False(t, fresh.Migrator().HasTable(&models.AuditEntry{}))
+
+	require.NoError(t, database.Migrate(fresh))
+	assert.True(t, fresh.Migrator().HasTable(&models.Person{}))
+}
@@ -3,13 +3,23 @@ package tests
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"person-service/database"
+	"person-service/docs"
+	"person-service/events"
 	"person-service/handlers"
+	"person-service/metrics"
+	"person-service/middleware"
 	"person-service/models"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -17,6 +27,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/testcontainers/testcontainers-go"
 	postgresContainer "github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
@@ -24,11 +36,22 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	testAllowedOrigin = "https://app.example.com"
+	testMaxBodyBytes  = 1 << 20
+)
+
+// testTenantID is the tenant of requests that don't send X-Tenant-ID and of
+// persons seeded directly through db.
+var testTenantID = uuid.MustParse("5f0c6a43-8f0e-4c1a-9d59-2d3c0b6a1e01")
+
 var (
 	router    *gin.Engine
 	db        *gorm.DB
 	container *postgresContainer.PostgresContainer
 	ctx       context.Context
+
+	stopListening context.CancelFunc = func() {}
 )
 
 func TestMain(m *testing.M) {
@@ -85,7 +108,54 @@ func setupTestContainer() error {
 	gin.SetMode(gin.TestMode)
 
 	var err error
-	container, err = postgresContainer.RunContainer(ctx,
+	container, db, err = startPostgres(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := database.Migrate(db); err != nil {
+		return fmt.Errorf("failed to migrate test database: %w", err)
+	}
+	if err := database.RegisterTenantScope(db); err != nil {
+		return fmt.Errorf("failed to register tenant scope: %w", err)
+	}
+
+	personChanges := events.NewBroker[events.PersonChange](64)
+	var listenCtx context.Context
+	listenCtx, stopListening = context.WithCancel(ctx)
+	go database.Listen(listenCtx, db, database.PersonChangesChannel, func(payload string) {
+		if change, err := events.ParsePersonChange(payload); err == nil {
+			personChanges.Publish(change)
+		}
+	})
+
+	personHandler := handlers.NewPersonHandler(db, handlers.WithChangeFeed(personChanges))
+	healthHandler := handlers.NewHealthHandler(db)
+	appMetrics := metrics.New(db)
+	if err := appMetrics.InstrumentDB(db); err != nil {
+		return fmt.Errorf("failed to register database metrics: %w", err)
+	}
+	router = gin.New()
+	router.Use(middleware.Recovery())
+	router.Use(middleware.RequestID())
+	router.Use(appMetrics.Middleware())
+	router.Use(middleware.CORS([]string{testAllowedOrigin}))
+	router.Use(middleware.BodyLimit(testMaxBodyBytes))
+	router.GET("/livez", healthHandler.Live)
+	router.GET("/readyz", healthHandler.Ready)
+	router.GET("/health", healthHandler.Ready)
+	router.GET("/metrics", appMetrics.Handler())
+	router.GET("/openapi.json", docs.Handler)
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/openapi.json")))
+	handlers.RegisterRoutes(router.Group("/v1", defaultTestTenant, middleware.Tenant()), personHandler)
+	handlers.RegisterRoutes(router.Group("", defaultTestTenant, middleware.Tenant(), middleware.Deprecated("", "/v1")), personHandler)
+
+	return nil
+}
+
+// startPostgres starts an empty PostgreSQL container and connects to it.
+func startPostgres(ctx context.Context) (*postgresContainer.PostgresContainer, *gorm.DB, error) {
+	pg, err := postgresContainer.RunContainer(ctx,
 		testcontainers.WithImage("postgres:15-alpine"),
 		postgresContainer.WithDatabase("persons_test"),
 		postgresContainer.WithUsername("testuser"),
@@ -97,35 +167,32 @@ func setupTestContainer() error {
 		),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to start postgres container: %w", err)
+		return nil, nil, fmt.Errorf("failed to start postgres container: %w", err)
 	}
 
-	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	connStr, err := pg.ConnectionString(ctx, "sslmode=disable")
 	if err != nil {
-		return fmt.Errorf("failed to get connection string: %w", err)
+		return pg, nil, fmt.Errorf("failed to get connection string: %w", err)
 	}
 
-	db, err = gorm.Open(postgres.Open(connStr), &gorm.Config{})
+	conn, err := gorm.Open(postgres.Open(connStr), &gorm.Config{})
 	if err != nil {
-		return fmt.Errorf("failed to connect to test database: %w", err)
-	}
-
-	if err := db.AutoMigrate(&models.Person{}); err != nil {
-		return fmt.Errorf("failed to migrate test database: %w", err)
+		return pg, nil, fmt.Errorf("failed to connect to test database: %w", err)
 	}
 
-	personHandler := handlers.NewPersonHandler(db)
-	router = gin.New()
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "ok"})
-	})
-	router.POST("/save", personHandler.SavePerson)
-	router.GET("/:id", personHandler.GetPerson)
+	return pg, conn, nil
+}
 
-	return nil
+// defaultTestTenant sends requests that don't pick a tenant as testTenantID,
+// so only the multi-tenancy tests need to set X-Tenant-ID.
+func defaultTestTenant(c *gin.Context) {
+	if c.GetHeader(middleware.TenantHeader) == "" {
+		c.Request.Header.Set(middleware.TenantHeader, testTenantID.String())
+	}
 }
 
 func teardown() {
+	stopListening()
 	if container != nil {
 		if err := container.Terminate(ctx); err != nil {
 			fmt.Printf("Failed to terminate container: %v\n", err)
@@ -135,7 +202,7 @@ func teardown() {
 
 func cleanTestData() {
 	if db != nil {
-		db.Where("name LIKE ? OR name LIKE ?", "Test%", "%Test%").Delete(&models.Person{})
+		db.Unscoped().Where("name LIKE ? OR name LIKE ?", "Test%", "%Test%").Delete(&models.Person{})
 	}
 }
 
@@ -152,6 +219,65 @@ func TestHealthCheck(t *testing.T) {
 	assert.Equal(t, "ok", response["status"])
 }
 
+func TestLivez(t *testing.T) {
+	req := httptest.NewRequest("GET", "/livez", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]string
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", response["status"])
+}
+
+func TestReadyz(t *testing.T) {
+	req := httptest.NewRequest("GET", "/readyz", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]string
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", response["status"])
+}
+
+func TestReadyzDatabaseUnavailable(t *testing.T) {
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	brokenDB, err := gorm.Open(postgres.Open(connStr), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := brokenDB.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+
+	healthHandler := handlers.NewHealthHandler(brokenDB)
+	healthRouter := gin.New()
+	healthRouter.GET("/livez", healthHandler.Live)
+	healthRouter.GET("/readyz", healthHandler.Ready)
+
+	req := httptest.NewRequest("GET", "/readyz", nil)
+	w := httptest.NewRecorder()
+	healthRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var response map[string]string
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "unavailable", response["status"])
+
+	req = httptest.NewRequest("GET", "/livez", nil)
+	w = httptest.NewRecorder()
+	healthRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
 func TestSavePersonSuccess(t *testing.T) {
 	cleanTestData()
 
@@ -160,7 +286,7 @@ func TestSavePersonSuccess(t *testing.T) {
 		ExternalID:  externalID,
 		Name:        "Test User John",
 		Email:       "testjohn@example.com",
-		DateOfBirth: time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC),
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
@@ -180,16 +306,66 @@ func TestSavePersonSuccess(t *testing.T) {
 	assert.Equal(t, "Test User John", response.Name)
 }
 
+func TestSavePersonRejectsFormBody(t *testing.T) {
+	cleanTestData()
+
+	req := httptest.NewRequest("POST", "/v1/save", strings.NewReader("name=Test+Form&email=testform%40example.com"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	var response models.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, middleware.UnsupportedMediaTypeMessage, response.Error)
+
+	var count int64
+	require.NoError(t, db.Model(&models.Person{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestSavePersonLocationIsFetchable(t *testing.T) {
+	cleanTestData()
+
+	jsonBody, err := json.Marshal(models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Location",
+		Email:       "testlocation@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/v1/save", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var created models.PersonResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	location := w.Header().Get("Location")
+	assert.Equal(t, "/v1/"+created.ExternalID.String(), location)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", location, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var fetched models.PersonResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &fetched))
+	assert.Equal(t, created, fetched)
+}
+
 func TestSavePersonDuplicateExternalID(t *testing.T) {
 	cleanTestData()
 
 	externalID := uuid.New()
 
 	person1 := models.Person{
+		TenantID:    testTenantID,
 		ExternalID:  externalID,
 		Name:        "Test First Person",
 		Email:       "testfirst@example.com",
-		DateOfBirth: time.Now(),
+		DateOfBirth: models.DateOf(time.Now()),
 	}
 	err := db.Create(&person1).Error
 	require.NoError(t, err)
@@ -198,7 +374,7 @@ func TestSavePersonDuplicateExternalID(t *testing.T) {
 		ExternalID:  externalID,
 		Name:        "Test Second Person",
 		Email:       "testsecond@example.com",
-		DateOfBirth: time.Now(),
+		DateOfBirth: models.DateOf(time.Now()),
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
@@ -221,10 +397,11 @@ func TestGetPersonSuccess(t *testing.T) {
 	cleanTestData()
 
 	person := models.Person{
+		TenantID:    testTenantID,
 		ExternalID:  uuid.New(),
 		Name:        "Test Jane Doe",
 		Email:       "testjane@example.com",
-		DateOfBirth: time.Date(1985, 6, 15, 10, 30, 0, 0, time.UTC),
+		DateOfBirth: models.DateOf(time.Date(1985, 6, 15, 10, 30, 0, 0, time.UTC)),
 	}
 	err := db.Create(&person).Error
 	require.NoError(t, err)
@@ -240,6 +417,33 @@ func TestGetPersonSuccess(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, person.ExternalID, response.ExternalID)
 	assert.Equal(t, "Test Jane Doe", response.Name)
+	assert.WithinDuration(t, person.CreatedAt, response.CreatedAt, time.Millisecond)
+	assert.WithinDuration(t, person.UpdatedAt, response.UpdatedAt, time.Millisecond)
+}
+
+func TestGetPersonByUUIDPath(t *testing.T) {
+	cleanTestData()
+	persons := seedPersons(t, "Test UUID Path")
+
+	req := httptest.NewRequest("GET", "/v1/"+persons[0].ExternalID.String(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.PersonResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, persons[0].ExternalID, response.ExternalID)
+	assert.Equal(t, "Test UUID Path", response.Name)
+}
+
+func TestGetPersonByUUIDPathNotFound(t *testing.T) {
+	req := httptest.NewRequest("GET", "/v1/"+uuid.New().String(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
 func TestGetPersonNotFound(t *testing.T) {
@@ -276,7 +480,7 @@ func TestSavePersonInvalidEmail(t *testing.T) {
 		ExternalID:  externalID,
 		Name:        "Test User",
 		Email:       "invalid-email",
-		DateOfBirth: time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC),
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
@@ -287,7 +491,7 @@ func TestSavePersonInvalidEmail(t *testing.T) {
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
 
 	var errorResponse models.ErrorResponse
 	err = json.Unmarshal(w.Body.Bytes(), &errorResponse)
@@ -295,6 +499,22 @@ func TestSavePersonInvalidEmail(t *testing.T) {
 	assert.Contains(t, errorResponse.Error, "Invalid request")
 }
 
+func TestSavePersonRejectsNilExternalID(t *testing.T) {
+	cleanTestData()
+
+	code, _ := postSave(t, "/v1/save", models.SavePersonRequest{
+		ExternalID:  uuid.Nil,
+		Name:        "Test Nil External ID",
+		Email:       "testnilexternalid@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+
+	var count int64
+	require.NoError(t, db.Model(&models.Person{}).Where("email = ?", "testnilexternalid@example.com").Count(&count).Error)
+	assert.Zero(t, count)
+}
+
 func TestSavePersonMissingFields(t *testing.T) {
 	cleanTestData()
 
@@ -312,10 +532,1468 @@ func TestSavePersonMissingFields(t *testing.T) {
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
 
 	var errorResponse models.ErrorResponse
 	err = json.Unmarshal(w.Body.Bytes(), &errorResponse)
 	require.NoError(t, err)
 	assert.Contains(t, errorResponse.Error, "Invalid request")
 }
+
+func TestUpdatePersonSuccess(t *testing.T) {
+	cleanTestData()
+
+	person := models.Person{
+		TenantID:    testTenantID,
+		ExternalID:  uuid.New(),
+		Name:        "Test Before Update",
+		Email:       "testbefore@example.com",
+		DateOfBirth: models.DateOf(time.Date(1980, 3, 10, 0, 0, 0, 0, time.UTC)),
+	}
+	err := db.Create(&person).Error
+	require.NoError(t, err)
+
+	reqBody := models.UpdatePersonRequest{
+		Name:        "Test After Update",
+		Email:       "testafter@example.com",
+		DateOfBirth: models.DateOf(time.Date(1981, 4, 11, 0, 0, 0, 0, time.UTC)),
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/%d", person.ID), bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.PersonResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, person.ExternalID, response.ExternalID)
+	assert.Equal(t, "Test After Update", response.Name)
+	assert.Equal(t, "testafter@example.com", response.Email)
+	assert.True(t, response.UpdatedAt.After(response.CreatedAt))
+
+	var stored models.Person
+	err = db.First(&stored, person.ID).Error
+	require.NoError(t, err)
+	assert.True(t, stored.UpdatedAt.After(person.UpdatedAt))
+}
+
+func TestUpdatePersonNotFound(t *testing.T) {
+	reqBody := models.UpdatePersonRequest{
+		Name:        "Test Nobody",
+		Email:       "testnobody@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("PUT", "/999999", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	var errorResponse models.ErrorResponse
+	err = json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "Person not found", errorResponse.Error)
+}
+
+func TestUpdatePersonEmptyName(t *testing.T) {
+	cleanTestData()
+
+	person := models.Person{
+		TenantID:    testTenantID,
+		ExternalID:  uuid.New(),
+		Name:        "Test Keep Name",
+		Email:       "testkeep@example.com",
+		DateOfBirth: models.DateOf(time.Date(1980, 3, 10, 0, 0, 0, 0, time.UTC)),
+	}
+	err := db.Create(&person).Error
+	require.NoError(t, err)
+
+	reqBody := models.UpdatePersonRequest{
+		Name:        "   ",
+		Email:       "testkeep@example.com",
+		DateOfBirth: models.DateOf(time.Date(1980, 3, 10, 0, 0, 0, 0, time.UTC)),
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("PUT", fmt.Sprintf("/%d", person.ID), bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	var errorResponse models.ErrorResponse
+	err = json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Contains(t, errorResponse.Error, "Validation error")
+}
+
+func TestDeletePersonSuccess(t *testing.T) {
+	cleanTestData()
+
+	person := models.Person{
+		TenantID:    testTenantID,
+		ExternalID:  uuid.New(),
+		Name:        "Test To Delete",
+		Email:       "testdelete@example.com",
+		DateOfBirth: models.DateOf(time.Date(1975, 8, 20, 0, 0, 0, 0, time.UTC)),
+	}
+	err := db.Create(&person).Error
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("DELETE", fmt.Sprintf("/%d", person.ID), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.Bytes())
+
+	req = httptest.NewRequest("GET", fmt.Sprintf("/%d", person.ID), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDeletePersonNotFound(t *testing.T) {
+	req := httptest.NewRequest("DELETE", "/999999", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "Person not found", errorResponse.Error)
+}
+
+func TestDeletePersonIsSoftDelete(t *testing.T) {
+	cleanTestData()
+
+	person := models.Person{
+		TenantID:    testTenantID,
+		ExternalID:  uuid.New(),
+		Name:        "Test Soft Delete",
+		Email:       "testsoft@example.com",
+		DateOfBirth: models.DateOf(time.Date(1970, 2, 2, 0, 0, 0, 0, time.UTC)),
+	}
+	err := db.Create(&person).Error
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("DELETE", fmt.Sprintf("/%d", person.ID), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	var stored models.Person
+	err = db.Unscoped().First(&stored, person.ID).Error
+	require.NoError(t, err)
+	assert.True(t, stored.DeletedAt.Valid)
+
+	req = httptest.NewRequest("GET", fmt.Sprintf("/%d?include_deleted=true", person.ID), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.PersonResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, person.ExternalID, response.ExternalID)
+	assert.NotNil(t, response.DeletedAt)
+}
+
+func TestSavePersonReusesSoftDeletedExternalID(t *testing.T) {
+	cleanTestData()
+
+	externalID := uuid.New()
+	person := models.Person{
+		TenantID:    testTenantID,
+		ExternalID:  externalID,
+		Name:        "Test Deleted Owner",
+		Email:       "testdeletedowner@example.com",
+		DateOfBirth: models.DateOf(time.Date(1970, 2, 2, 0, 0, 0, 0, time.UTC)),
+	}
+	err := db.Create(&person).Error
+	require.NoError(t, err)
+	err = db.Delete(&person).Error
+	require.NoError(t, err)
+
+	reqBody := models.SavePersonRequest{
+		ExternalID:  externalID,
+		Name:        "Test New Owner",
+		Email:       "testnewowner@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/save", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func seedPersons(t *testing.T, names ...string) []models.Person {
+	t.Helper()
+
+	persons := make([]models.Person, 0, len(names))
+	for i, name := range names {
+		person := models.Person{
+			TenantID:    testTenantID,
+			ExternalID:  uuid.New(),
+			Name:        name,
+			Email:       fmt.Sprintf("testseed%d@example.com", i),
+			DateOfBirth: models.DateOf(time.Date(1980+i, 1, 1, 0, 0, 0, 0, time.UTC)),
+		}
+		require.NoError(t, db.Create(&person).Error)
+		persons = append(persons, person)
+	}
+	return persons
+}
+
+func TestListPersonsPagination(t *testing.T) {
+	cleanTestData()
+	persons := seedPersons(t, "Test List A", "Test List B", "Test List C")
+
+	req := httptest.NewRequest("GET", "/persons?page=2&page_size=2", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.PersonListResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, 2, response.Page)
+	assert.Equal(t, 2, response.PageSize)
+	assert.Equal(t, int64(3), response.Total)
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, persons[2].ExternalID, response.Data[0].ExternalID)
+}
+
+func TestListPersonsInvalidParamsFallBackToDefaults(t *testing.T) {
+	cleanTestData()
+	seedPersons(t, "Test Default A")
+
+	req := httptest.NewRequest("GET", "/persons?page=-1&page_size=abc", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.PersonListResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.Page)
+	assert.Equal(t, 20, response.PageSize)
+	assert.Len(t, response.Data, 1)
+}
+
+func TestListPersonsCapsPageSize(t *testing.T) {
+	req := httptest.NewRequest("GET", "/persons?page_size=1000", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.PersonListResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, 100, response.PageSize)
+}
+
+func TestGetPersonByExternalIDSuccess(t *testing.T) {
+	cleanTestData()
+	persons := seedPersons(t, "Test External Lookup")
+
+	req := httptest.NewRequest("GET", "/persons/by-external/"+persons[0].ExternalID.String(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.PersonResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, persons[0].ExternalID, response.ExternalID)
+	assert.Equal(t, "Test External Lookup", response.Name)
+}
+
+func TestGetPersonByExternalIDNotFound(t *testing.T) {
+	req := httptest.NewRequest("GET", "/persons/by-external/"+uuid.New().String(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "Person not found", errorResponse.Error)
+}
+
+func TestGetPersonByExternalIDMalformed(t *testing.T) {
+	req := httptest.NewRequest("GET", "/persons/by-external/not-a-uuid", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "Invalid ID format", errorResponse.Error)
+}
+
+func TestSavePersonNormalizesEmail(t *testing.T) {
+	cleanTestData()
+
+	reqBody := models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Mixed Case",
+		Email:       "Test.Mixed@Example.COM",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/save", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response models.PersonResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "test.mixed@example.com", response.Email)
+
+	var stored models.Person
+	err = db.Where("external_id = ?", reqBody.ExternalID).First(&stored).Error
+	require.NoError(t, err)
+	assert.Equal(t, "test.mixed@example.com", stored.Email)
+}
+
+func TestSavePersonDuplicateEmail(t *testing.T) {
+	cleanTestData()
+
+	for i, expected := range []int{http.StatusCreated, http.StatusConflict} {
+		reqBody := models.SavePersonRequest{
+			ExternalID:  uuid.New(),
+			Name:        fmt.Sprintf("Test Same Email %d", i),
+			Email:       "testsame@example.com",
+			DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+		}
+
+		jsonBody, err := json.Marshal(reqBody)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("POST", "/save", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, expected, w.Code)
+		if expected == http.StatusConflict {
+			var errorResponse models.ErrorResponse
+			err = json.Unmarshal(w.Body.Bytes(), &errorResponse)
+			require.NoError(t, err)
+			assert.Equal(t, "Person with this email already exists", errorResponse.Error)
+		}
+	}
+}
+
+func TestRequestIDEchoed(t *testing.T) {
+	req := httptest.NewRequest("GET", "/livez", nil)
+	req.Header.Set("X-Request-ID", "test-request-id")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "test-request-id", w.Header().Get("X-Request-ID"))
+}
+
+func TestRequestIDGeneratedAndIncludedInErrors(t *testing.T) {
+	req := httptest.NewRequest("GET", "/999999", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	requestID := w.Header().Get("X-Request-ID")
+	_, err := uuid.Parse(requestID)
+	require.NoError(t, err)
+
+	var errorResponse models.ErrorResponse
+	err = json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, requestID, errorResponse.RequestID)
+}
+
+func TestCORSPreflightAllowedOrigin(t *testing.T) {
+	req := httptest.NewRequest("OPTIONS", "/save", nil)
+	req.Header.Set("Origin", testAllowedOrigin)
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, testAllowedOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), "POST")
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "Content-Type")
+}
+
+func TestCORSPreflightDisallowedOrigin(t *testing.T) {
+	req := httptest.NewRequest("OPTIONS", "/save", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORSActualRequest(t *testing.T) {
+	req := httptest.NewRequest("GET", "/livez", nil)
+	req.Header.Set("Origin", testAllowedOrigin)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, testAllowedOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+
+	req = httptest.NewRequest("GET", "/livez", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	req := httptest.NewRequest("GET", "/livez", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest("GET", "/metrics", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Contains(t, body, "person_service_http_requests_total")
+	assert.Contains(t, body, "person_service_http_request_duration_seconds")
+	assert.Contains(t, body, "person_service_persons_total")
+	assert.Contains(t, body, `route="/livez"`)
+}
+
+func TestSavePersonValidationDetails(t *testing.T) {
+	reqBody := map[string]interface{}{
+		"external_id":   uuid.New(),
+		"email":         "not-an-email",
+		"date_of_birth": "1990-01-01T12:00:00Z",
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/save", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	var errorResponse models.ErrorResponse
+	err = json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Contains(t, errorResponse.Error, "Invalid request")
+	assert.ElementsMatch(t, []models.FieldError{
+		{Field: "name", Message: "is required unless first_name or last_name is given"},
+		{Field: "email", Message: "must be a valid email address"},
+	}, errorResponse.Details)
+}
+
+func TestPatchPersonUpdatesOnlyProvidedFields(t *testing.T) {
+	cleanTestData()
+	persons := seedPersons(t, "Test Patch Me")
+
+	jsonBody := []byte(`{"email": "TestPatched@Example.com"}`)
+
+	req := httptest.NewRequest("PATCH", fmt.Sprintf("/%d", persons[0].ID), bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.PersonResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "testpatched@example.com", response.Email)
+	assert.Equal(t, "Test Patch Me", response.Name)
+	assert.Equal(t, persons[0].DateOfBirth, response.DateOfBirth)
+}
+
+func TestPatchPersonRejectsFutureDateOfBirth(t *testing.T) {
+	cleanTestData()
+	persons := seedPersons(t, "Test Patch Future")
+
+	jsonBody, err := json.Marshal(map[string]interface{}{
+		"date_of_birth": time.Now().AddDate(1, 0, 0),
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("PATCH", fmt.Sprintf("/%d", persons[0].ID), bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestPatchPersonRejectsInvalidEmail(t *testing.T) {
+	cleanTestData()
+	persons := seedPersons(t, "Test Patch Email")
+
+	jsonBody := []byte(`{"email": "not-an-email"}`)
+
+	req := httptest.NewRequest("PATCH", fmt.Sprintf("/%d", persons[0].ID), bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestPatchPersonNotFound(t *testing.T) {
+	jsonBody := []byte(`{"name": "Test Nobody"}`)
+
+	req := httptest.NewRequest("PATCH", "/999999", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSavePersonsBatch(t *testing.T) {
+	cleanTestData()
+	existing := seedPersons(t, "Test Batch Existing")
+
+	reqBody := []models.SavePersonRequest{
+		{
+			ExternalID:  uuid.New(),
+			Name:        "Test Batch Valid",
+			Email:       "testbatchvalid@example.com",
+			DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+		},
+		{
+			ExternalID:  uuid.New(),
+			Name:        "Test Batch Bad Email",
+			Email:       "not-an-email",
+			DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+		},
+		{
+			ExternalID:  existing[0].ExternalID,
+			Name:        "Test Batch Duplicate",
+			Email:       "testbatchdup@example.com",
+			DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+		},
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/save/batch", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+
+	var response models.BatchSaveResponse
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, 1, response.Created)
+	assert.Equal(t, 2, response.Failed)
+	require.Len(t, response.Results, 3)
+
+	assert.Equal(t, http.StatusCreated, response.Results[0].Status)
+	require.NotNil(t, response.Results[0].Person)
+	assert.Equal(t, reqBody[0].ExternalID, response.Results[0].Person.ExternalID)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, response.Results[1].Status)
+	assert.Contains(t, response.Results[1].Error, "Invalid request")
+
+	assert.Equal(t, http.StatusConflict, response.Results[2].Status)
+	assert.Contains(t, response.Results[2].Error, "already exists")
+
+	var count int64
+	err = db.Model(&models.Person{}).Where("external_id = ?", reqBody[0].ExternalID).Count(&count).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestSavePersonsBatchPublishesInOneBatch(t *testing.T) {
+	cleanTestData()
+
+	publisher := &fakePublisher{}
+	batchRouter := gin.New()
+	batchRouter.POST("/save/batch", handlers.NewPersonHandler(db, handlers.WithPublisher(publisher)).SavePersonsBatch)
+
+	jsonBody, err := json.Marshal([]models.SavePersonRequest{
+		{
+			ExternalID:  uuid.New(),
+			Name:        "Test Batch Published One",
+			Email:       "testbatchpublished1@example.com",
+			DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+		},
+		{
+			ExternalID:  uuid.New(),
+			Name:        "Test Batch Published Two",
+			Email:       "testbatchpublished2@example.com",
+			DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+		},
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/save/batch", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	batchRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	assert.Equal(t, 1, publisher.calls)
+	assert.Len(t, publisher.created, 2)
+}
+
+func TestSavePersonsBatchEmpty(t *testing.T) {
+	req := httptest.NewRequest("POST", "/save/batch", bytes.NewBufferString("[]"))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func postSaveWithIdempotencyKey(t *testing.T, reqBody models.SavePersonRequest, key string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	jsonBody, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/save", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", key)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSavePersonIdempotencyKeyReplay(t *testing.T) {
+	cleanTestData()
+
+	key := uuid.NewString()
+	reqBody := models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Idempotent",
+		Email:       "testidempotent@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+
+	first := postSaveWithIdempotencyKey(t, reqBody, key)
+	require.Equal(t, http.StatusCreated, first.Code)
+
+	second := postSaveWithIdempotencyKey(t, reqBody, key)
+	assert.Equal(t, http.StatusOK, second.Code)
+
+	var firstResponse, secondResponse models.PersonResponse
+	require.NoError(t, json.Unmarshal(first.Body.Bytes(), &firstResponse))
+	require.NoError(t, json.Unmarshal(second.Body.Bytes(), &secondResponse))
+	assert.Equal(t, firstResponse.ExternalID, secondResponse.ExternalID)
+	assert.Equal(t, firstResponse.Name, secondResponse.Name)
+
+	var count int64
+	err := db.Model(&models.Person{}).Where("external_id = ?", reqBody.ExternalID).Count(&count).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestSavePersonDifferentIdempotencyKeys(t *testing.T) {
+	cleanTestData()
+
+	first := postSaveWithIdempotencyKey(t, models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Key One",
+		Email:       "testkeyone@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}, uuid.NewString())
+	assert.Equal(t, http.StatusCreated, first.Code)
+
+	second := postSaveWithIdempotencyKey(t, models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Key Two",
+		Email:       "testkeytwo@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}, uuid.NewString())
+	assert.Equal(t, http.StatusCreated, second.Code)
+
+	var firstResponse, secondResponse models.PersonResponse
+	require.NoError(t, json.Unmarshal(first.Body.Bytes(), &firstResponse))
+	require.NoError(t, json.Unmarshal(second.Body.Bytes(), &secondResponse))
+	assert.NotEqual(t, firstResponse.ExternalID, secondResponse.ExternalID)
+}
+
+func listPersons(t *testing.T, query string) models.PersonListResponse {
+	t.Helper()
+
+	req := httptest.NewRequest("GET", "/persons?"+query, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response models.PersonListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
+
+func TestListPersonsFilters(t *testing.T) {
+	cleanTestData()
+	// seedPersons assigns birth years 1980, 1981, 1982 and emails testseed0..2.
+	seedPersons(t, "Test Filter Alice", "Test Filter Bob", "Test Filter Alicia")
+
+	byName := listPersons(t, "name=ALIC")
+	assert.Equal(t, int64(2), byName.Total)
+
+	byEmail := listPersons(t, "email=TestSeed1@example.com")
+	require.Len(t, byEmail.Data, 1)
+	assert.Equal(t, "Test Filter Bob", byEmail.Data[0].Name)
+
+	byDate := listPersons(t, "born_after=1980-06-01T00:00:00Z&born_before=1982-01-01")
+	require.Len(t, byDate.Data, 1)
+	assert.Equal(t, "Test Filter Bob", byDate.Data[0].Name)
+
+	combined := listPersons(t, "name=alic&born_after=1981-01-01")
+	require.Len(t, combined.Data, 1)
+	assert.Equal(t, "Test Filter Alicia", combined.Data[0].Name)
+}
+
+func TestListPersonsNameFilterIgnoresAccents(t *testing.T) {
+	cleanTestData()
+	seedPersons(t, "Test Filter José", "Test Filter Jonas")
+
+	byName := listPersons(t, "name=jose")
+	require.Len(t, byName.Data, 1)
+	assert.Equal(t, "Test Filter José", byName.Data[0].Name)
+
+	assert.Len(t, listPersons(t, "name="+url.QueryEscape("JOSÉ")).Data, 1)
+}
+
+func TestListPersonsMalformedDateFilter(t *testing.T) {
+	req := httptest.NewRequest("GET", "/persons?born_after=yesterday", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestListPersonsSorting(t *testing.T) {
+	cleanTestData()
+	// Birth years follow seed order: Charlie 1980, Alice 1981, Bob 1982.
+	seedPersons(t, "Test Sort Charlie", "Test Sort Alice", "Test Sort Bob")
+
+	names := func(response models.PersonListResponse) []string {
+		result := make([]string, 0, len(response.Data))
+		for _, person := range response.Data {
+			result = append(result, person.Name)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"Test Sort Alice", "Test Sort Bob", "Test Sort Charlie"}, names(listPersons(t, "sort=name")))
+	assert.Equal(t, []string{"Test Sort Charlie", "Test Sort Bob", "Test Sort Alice"}, names(listPersons(t, "sort=-name")))
+	assert.Equal(t, []string{"Test Sort Charlie", "Test Sort Alice", "Test Sort Bob"}, names(listPersons(t, "sort=date_of_birth")))
+	assert.Equal(t, []string{"Test Sort Bob", "Test Sort Alice", "Test Sort Charlie"}, names(listPersons(t, "sort=-date_of_birth")))
+}
+
+func TestListPersonsSortingByLastName(t *testing.T) {
+	cleanTestData()
+	for _, parts := range [][2]string{{"Test", "Zeta"}, {"Test", ""}, {"Test", "Alpha"}} {
+		reqBody := models.SavePersonRequest{
+			ExternalID:  uuid.New(),
+			Name:        "Test Sort " + parts[1],
+			FirstName:   parts[0],
+			LastName:    parts[1],
+			Email:       fmt.Sprintf("testsort%s@example.com", strings.ToLower(parts[1])),
+			DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+		}
+		w := postSaveWithIdempotencyKey(t, reqBody, "")
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	lastNames := func(response models.PersonListResponse) []string {
+		result := make([]string, 0, len(response.Data))
+		for _, person := range response.Data {
+			result = append(result, person.LastName)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"Alpha", "Zeta", ""}, lastNames(listPersons(t, "sort=last_name")))
+	assert.Equal(t, []string{"Zeta", "Alpha", ""}, lastNames(listPersons(t, "sort=-last_name")))
+}
+
+func TestListPersonsUnknownSortKey(t *testing.T) {
+	req := httptest.NewRequest("GET", "/persons?sort=password", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Contains(t, errorResponse.Error, "unknown sort key")
+}
+
+func listPersonsByCursor(t *testing.T, query string) models.PersonCursorResponse {
+	t.Helper()
+
+	req := httptest.NewRequest("GET", "/v1/persons?"+query, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response models.PersonCursorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
+
+func TestListPersonsCursorWalksAllPages(t *testing.T) {
+	cleanTestData()
+	persons := seedPersons(t, "Test Cursor A", "Test Cursor B", "Test Cursor C", "Test Cursor D", "Test Cursor E")
+
+	var seen []uuid.UUID
+	var pageSizes []int
+	query := "limit=2"
+	for {
+		page := listPersonsByCursor(t, query)
+		assert.Equal(t, 2, page.Limit)
+		pageSizes = append(pageSizes, len(page.Data))
+		for _, person := range page.Data {
+			seen = append(seen, person.ExternalID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query = "limit=2&cursor=" + url.QueryEscape(page.NextCursor)
+	}
+
+	expected := make([]uuid.UUID, 0, len(persons))
+	for _, person := range persons {
+		expected = append(expected, person.ExternalID)
+	}
+	assert.Equal(t, []int{2, 2, 1}, pageSizes)
+	assert.Equal(t, expected, seen, "every person exactly once, in id order")
+}
+
+func TestListPersonsCursorUnaffectedByRemovedRows(t *testing.T) {
+	cleanTestData()
+	seedPersons(t, "Test Cursor First", "Test Cursor Second")
+
+	first := listPersonsByCursor(t, "limit=1")
+	require.Len(t, first.Data, 1)
+	require.NotEmpty(t, first.NextCursor)
+
+	// Deleting an already-seen row must not shift the next page.
+	require.NoError(t, db.Unscoped().Where("external_id = ?", first.Data[0].ExternalID).Delete(&models.Person{}).Error)
+
+	second := listPersonsByCursor(t, "limit=1&cursor="+url.QueryEscape(first.NextCursor))
+	require.Len(t, second.Data, 1)
+	assert.Equal(t, "Test Cursor Second", second.Data[0].Name)
+	assert.Empty(t, second.NextCursor)
+}
+
+func TestListPersonsRejectsInvalidCursor(t *testing.T) {
+	req := httptest.NewRequest("GET", "/v1/persons?cursor=not-a-cursor", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetPersonCanceledContext(t *testing.T) {
+	cleanTestData()
+	persons := seedPersons(t, "Test Canceled Context")
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/%d", persons[0].ID), nil).WithContext(canceledCtx)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "Database request timed out", errorResponse.Error)
+}
+
+func TestUpdatePersonOptimisticLocking(t *testing.T) {
+	cleanTestData()
+	persons := seedPersons(t, "Test Locked")
+
+	put := func(name string) *httptest.ResponseRecorder {
+		jsonBody, err := json.Marshal(models.UpdatePersonRequest{
+			Name:        name,
+			Email:       "testlocked@example.com",
+			DateOfBirth: models.DateOf(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)),
+		})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("PUT", fmt.Sprintf("/%d", persons[0].ID), bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"0"`)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Both writers read version 0; only the first one may win.
+	first := put("Test Locked First")
+	assert.Equal(t, http.StatusOK, first.Code)
+
+	var response models.PersonResponse
+	require.NoError(t, json.Unmarshal(first.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Version)
+
+	second := put("Test Locked Second")
+	assert.Equal(t, http.StatusConflict, second.Code)
+
+	var stored models.Person
+	require.NoError(t, db.First(&stored, persons[0].ID).Error)
+	assert.Equal(t, "Test Locked First", stored.Name)
+	assert.Equal(t, 1, stored.Version)
+}
+
+type fakePublisher struct {
+	calls   int
+	created []models.Person
+	err     error
+}
+
+func (p *fakePublisher) PublishCreated(_ context.Context, persons ...models.Person) error {
+	p.calls++
+	p.created = append(p.created, persons...)
+	return p.err
+}
+
+func newPublisherRouter(publisher *fakePublisher) *gin.Engine {
+	publisherRouter := gin.New()
+	publisherRouter.POST("/save", handlers.NewPersonHandler(db, handlers.WithPublisher(publisher)).SavePerson)
+	return publisherRouter
+}
+
+func TestSavePersonPublishesCreatedEvent(t *testing.T) {
+	cleanTestData()
+
+	publisher := &fakePublisher{}
+	reqBody := models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Published",
+		Email:       "testpublished@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/save", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	newPublisherRouter(publisher).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	require.Len(t, publisher.created, 1)
+	assert.Equal(t, reqBody.ExternalID, publisher.created[0].ExternalID)
+	assert.NotZero(t, publisher.created[0].ID)
+}
+
+func TestSavePersonSucceedsWhenPublishFails(t *testing.T) {
+	cleanTestData()
+
+	publisher := &fakePublisher{err: fmt.Errorf("broker unavailable")}
+	reqBody := models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Publish Failure",
+		Email:       "testpublishfailure@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/save", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	newPublisherRouter(publisher).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Len(t, publisher.created, 1)
+}
+
+func countPersons(t *testing.T, query string) int64 {
+	t.Helper()
+
+	req := httptest.NewRequest("GET", "/persons/count?"+query, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response models.CountResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response.Count
+}
+
+func TestCountPersons(t *testing.T) {
+	cleanTestData()
+	seedPersons(t, "Test Count Anna", "Test Count Ben", "Test Count Annika")
+
+	assert.Equal(t, int64(3), countPersons(t, ""))
+	assert.Equal(t, int64(2), countPersons(t, "name=ann"))
+	assert.Equal(t, int64(1), countPersons(t, "name=ann&born_after=1981-01-01"))
+}
+
+func TestExportPersonsCSV(t *testing.T) {
+	cleanTestData()
+
+	person := models.Person{
+		TenantID:    testTenantID,
+		ExternalID:  uuid.New(),
+		Name:        `Test "Quoted", Name`,
+		Email:       "testcsv@example.com",
+		DateOfBirth: models.DateOf(time.Date(1985, 6, 15, 0, 0, 0, 0, time.UTC)),
+	}
+	require.NoError(t, db.Create(&person).Error)
+
+	req := httptest.NewRequest("GET", "/persons/export.csv", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/csv")
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "persons.csv")
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, []string{"external_id", "name", "email", "date_of_birth", "created_at"}, records[0])
+	assert.Equal(t, person.ExternalID.String(), records[1][0])
+	assert.Equal(t, `Test "Quoted", Name`, records[1][1])
+	assert.Equal(t, "1985-06-15", records[1][3])
+}
+
+func importCSV(t *testing.T, content string) (int, models.ImportSummary) {
+	t.Helper()
+	return importCSVTo(t, router, content)
+}
+
+func importCSVTo(t *testing.T, r *gin.Engine, content string) (int, models.ImportSummary) {
+	t.Helper()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", "persons.csv")
+	require.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+
+	req := httptest.NewRequest("POST", "/persons/import", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var summary models.ImportSummary
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &summary))
+	}
+	return w.Code, summary
+}
+
+func TestImportPersonsCSV(t *testing.T) {
+	cleanTestData()
+
+	first, second := uuid.New(), uuid.New()
+	content := "external_id,name,email,date_of_birth,created_at\n" +
+		fmt.Sprintf("%s,Test Import One,testimport1@example.com,1985-06-15,\n", first) +
+		fmt.Sprintf("%s,Test Import Two,testimport2@example.com,1990-01-02T00:00:00Z,\n", second)
+
+	code, summary := importCSV(t, content)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 2, summary.Imported)
+	assert.Equal(t, 0, summary.Skipped)
+	assert.Empty(t, summary.Errors)
+
+	var stored models.Person
+	require.NoError(t, db.Where("external_id = ?", first).First(&stored).Error)
+	assert.Equal(t, "Test Import One", stored.Name)
+	assert.Equal(t, models.NewDate(1985, time.June, 15), stored.DateOfBirth)
+}
+
+func TestImportPersonsCSVBadRow(t *testing.T) {
+	cleanTestData()
+
+	content := "external_id,name,email,date_of_birth\n" +
+		fmt.Sprintf("%s,Test Import Good,testimportgood@example.com,1985-06-15\n", uuid.New()) +
+		fmt.Sprintf("%s,Test Import Bad,not-an-email,1985-06-15\n", uuid.New())
+
+	code, summary := importCSV(t, content)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 1, summary.Imported)
+	require.Len(t, summary.Errors, 1)
+	assert.Equal(t, 3, summary.Errors[0].Line)
+}
+
+func TestImportPersonsCSVSkipsDuplicates(t *testing.T) {
+	cleanTestData()
+
+	existing := seedPersons(t, "Test Existing")[0]
+	fresh := uuid.New()
+	content := "external_id,name,email,date_of_birth\n" +
+		fmt.Sprintf("%s,Test Existing Again,testimportdup@example.com,1985-06-15\n", existing.ExternalID) +
+		fmt.Sprintf("%s,Test Import Fresh,testimportfresh@example.com,1985-06-15\n", fresh) +
+		fmt.Sprintf("%s,Test Import Fresh Again,testimportfresh2@example.com,1985-06-15\n", fresh)
+
+	code, summary := importCSV(t, content)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 1, summary.Imported)
+	assert.Equal(t, 2, summary.Skipped)
+	assert.Empty(t, summary.Errors)
+}
+
+func TestImportPersonsCSVPublishesInOneBatch(t *testing.T) {
+	cleanTestData()
+
+	publisher := &fakePublisher{}
+	importRouter := gin.New()
+	importRouter.POST("/persons/import", handlers.NewPersonHandler(db, handlers.WithPublisher(publisher)).ImportPersonsCSV)
+
+	content := "external_id,name,email,date_of_birth\n" +
+		fmt.Sprintf("%s,Test Import One,testimport1@example.com,1985-06-15\n", uuid.New()) +
+		fmt.Sprintf("%s,Test Import Two,testimport2@example.com,1985-06-15\n", uuid.New())
+
+	code, summary := importCSVTo(t, importRouter, content)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 2, summary.Imported)
+	assert.Equal(t, 1, publisher.calls)
+	assert.Len(t, publisher.created, 2)
+}
+
+func TestImportPersonsCSVMissingColumn(t *testing.T) {
+	code, _ := importCSV(t, "external_id,name\n")
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestVersionedAndLegacyRoutes(t *testing.T) {
+	cleanTestData()
+
+	person := seedPersons(t, "Test Versioned")[0]
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/v1/%d", person.ID), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get(middleware.DeprecationHeader))
+
+	req = httptest.NewRequest("GET", fmt.Sprintf("/%d", person.ID), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "true", w.Header().Get(middleware.DeprecationHeader))
+	assert.Equal(t, fmt.Sprintf("</v1/%d>; rel=\"successor-version\"", person.ID), w.Header().Get("Link"))
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	req := httptest.NewRequest("GET", "/openapi.json", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var spec struct {
+		Paths       map[string]map[string]json.RawMessage `json:"paths"`
+		Definitions map[string]json.RawMessage            `json:"definitions"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+	assert.Contains(t, spec.Paths["/v1/save"], "post")
+	assert.Contains(t, spec.Paths["/v1/{id}"], "get")
+	assert.Contains(t, spec.Definitions, "models.SavePersonRequest")
+	assert.Contains(t, spec.Definitions, "models.PersonResponse")
+	assert.Contains(t, spec.Definitions, "models.ErrorResponse")
+
+	req = httptest.NewRequest("GET", "/swagger/index.html", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "/openapi.json")
+}
+
+func TestSavePersonConcurrentDuplicates(t *testing.T) {
+	cleanTestData()
+
+	reqBody := models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Concurrent",
+		Email:       "testconcurrent@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+
+	const attempts = 2
+	codes := make([]int, attempts)
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := range attempts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			codes[i] = postSaveWithIdempotencyKey(t, reqBody, "").Code
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	assert.ElementsMatch(t, []int{http.StatusCreated, http.StatusConflict}, codes)
+
+	var count int64
+	require.NoError(t, db.Model(&models.Person{}).Where("external_id = ?", reqBody.ExternalID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestPatchPersonDuplicateEmail(t *testing.T) {
+	cleanTestData()
+	persons := seedPersons(t, "Test Email Owner", "Test Email Taker")
+
+	jsonBody := []byte(fmt.Sprintf(`{"email": %q}`, persons[0].Email))
+
+	req := httptest.NewRequest("PATCH", fmt.Sprintf("/%d", persons[1].ID), bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var errorResponse models.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	assert.Equal(t, "Person with this email already exists", errorResponse.Error)
+}
+
+func TestSavePersonIdempotencyKeyReplayAfterDuplicate(t *testing.T) {
+	cleanTestData()
+
+	key := uuid.NewString()
+	reqBody := models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Idempotent Duplicate",
+		Email:       "testidempotentdup@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+	require.Equal(t, http.StatusCreated, postSaveWithIdempotencyKey(t, reqBody, key).Code)
+
+	// The replay collides on every unique index; it must still be answered
+	// from the stored request rather than as a conflict.
+	assert.Equal(t, http.StatusOK, postSaveWithIdempotencyKey(t, reqBody, key).Code)
+
+	// Without the key the same body is a genuine duplicate.
+	assert.Equal(t, http.StatusConflict, postSaveWithIdempotencyKey(t, reqBody, "").Code)
+}
+
+func TestSavePersonPhone(t *testing.T) {
+	cleanTestData()
+
+	tests := []struct {
+		name         string
+		phone        string
+		expectedCode int
+	}{
+		{"valid E.164", "+14155552671", http.StatusCreated},
+		{"invalid", "415-555-2671", http.StatusUnprocessableEntity},
+		{"omitted", "", http.StatusCreated},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqBody := models.SavePersonRequest{
+				ExternalID:  uuid.New(),
+				Name:        "Test Phone",
+				Email:       fmt.Sprintf("testphone%d@example.com", i),
+				Phone:       tt.phone,
+				DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+			}
+
+			w := postSaveWithIdempotencyKey(t, reqBody, "")
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			if tt.expectedCode == http.StatusUnprocessableEntity {
+				var errorResponse models.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+				require.Len(t, errorResponse.Details, 1)
+				assert.Equal(t, "phone", errorResponse.Details[0].Field)
+				return
+			}
+
+			var response models.PersonResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.phone, response.Phone)
+
+			var stored models.Person
+			require.NoError(t, db.Where("external_id = ?", reqBody.ExternalID).First(&stored).Error)
+			assert.Equal(t, tt.phone, stored.Phone)
+		})
+	}
+}
+
+func TestSavePersonWithStructuredNames(t *testing.T) {
+	cleanTestData()
+
+	reqBody := models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		FirstName:   "Test",
+		MiddleName:  "Q.",
+		LastName:    "Structured",
+		Email:       "teststructured@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+
+	w := postSaveWithIdempotencyKey(t, reqBody, "")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var response models.PersonResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "Test Q. Structured", response.Name)
+	assert.Equal(t, "Test", response.FirstName)
+	assert.Equal(t, "Q.", response.MiddleName)
+	assert.Equal(t, "Structured", response.LastName)
+
+	var stored models.Person
+	require.NoError(t, db.Where("external_id = ?", reqBody.ExternalID).First(&stored).Error)
+	assert.Equal(t, "Structured", stored.LastName)
+}
+
+func TestSavePersonWithAddressRoundTrip(t *testing.T) {
+	cleanTestData()
+
+	address := &models.Address{
+		Street:     "Unter den Linden 1",
+		City:       "Berlin",
+		Region:     "Berlin",
+		PostalCode: "10117",
+		Country:    "DE",
+	}
+	reqBody := models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Address",
+		Email:       "testaddress@example.com",
+		Address:     address,
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+
+	w := postSaveWithIdempotencyKey(t, reqBody, "")
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var stored models.Person
+	require.NoError(t, db.Where("external_id = ?", reqBody.ExternalID).First(&stored).Error)
+	assert.Equal(t, *address, stored.Address)
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/%d", stored.ID), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response models.PersonResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, address, response.Address)
+}
+
+func TestSavePersonRejectsInvalidCountry(t *testing.T) {
+	cleanTestData()
+
+	reqBody := models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Bad Country",
+		Email:       "testbadcountry@example.com",
+		Address:     &models.Address{City: "Berlin", Country: "Germany"},
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+
+	w := postSaveWithIdempotencyKey(t, reqBody, "")
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	var errorResponse models.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	assert.Equal(t, []models.FieldError{
+		{Field: "address.country", Message: "must be an ISO 3166-1 alpha-2 country code, e.g. DE"},
+	}, errorResponse.Details)
+}
+
+func TestSavePersonRejectsOversizedBody(t *testing.T) {
+	body := `{"name":"` + strings.Repeat("x", testMaxBodyBytes) + `"}`
+
+	req := httptest.NewRequest("POST", "/v1/save", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	var errorResponse models.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	assert.Equal(t, middleware.BodyTooLargeMessage, errorResponse.Error)
+}
+
+func TestSaveBatchRejectsOversizedStreamedBody(t *testing.T) {
+	body := `[{"name":"` + strings.Repeat("x", testMaxBodyBytes) + `"}]`
+
+	req := httptest.NewRequest("POST", "/v1/save/batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.ContentLength = -1
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
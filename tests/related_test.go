@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seedPersonWithEmail(t *testing.T, name, email string) models.Person {
+	t.Helper()
+
+	person := models.Person{
+		TenantID:    testTenantID,
+		ExternalID:  uuid.New(),
+		Name:        name,
+		Email:       email,
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	require.NoError(t, db.Create(&person).Error)
+	return person
+}
+
+func TestGetRelatedPersonsMatchesEmailDomain(t *testing.T) {
+	cleanTestData()
+	target := seedPersonWithEmail(t, "Test Related Target", "target@acme.example")
+	colleague := seedPersonWithEmail(t, "Test Related Colleague", "colleague@acme.example")
+	seedPersonWithEmail(t, "Test Related Other", "other@globex.example")
+	seedPersonWithEmail(t, "Test Related Subdomain", "sub@eu.acme.example")
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/v1/persons/%d/related", target.ID), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response models.PersonListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int64(1), response.Total)
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, colleague.ExternalID, response.Data[0].ExternalID)
+}
+
+func TestGetRelatedPersonsPaginates(t *testing.T) {
+	cleanTestData()
+	target := seedPersonWithEmail(t, "Test Related Target", "target@acme.example")
+	for i := 0; i < 3; i++ {
+		seedPersonWithEmail(t, fmt.Sprintf("Test Related %d", i), fmt.Sprintf("colleague%d@acme.example", i))
+	}
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/v1/persons/%d/related?page=2&page_size=2", target.ID), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response models.PersonListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int64(3), response.Total)
+	assert.Len(t, response.Data, 1)
+}
+
+func TestGetRelatedPersonsNotFound(t *testing.T) {
+	cleanTestData()
+
+	req := httptest.NewRequest("GET", "/v1/persons/999999/related", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
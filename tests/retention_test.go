@@ -0,0 +1,39 @@
+package tests
+
+import (
+	"context"
+	"person-service/database"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeCreatedBeforeDeletesExpiredPersons(t *testing.T) {
+	cleanTestData()
+	persons := seedPersons(t, "Test Retention Old", "Test Retention Old Deleted", "Test Retention New")
+	old := time.Now().AddDate(0, 0, -40)
+	require.NoError(t, db.Exec("UPDATE people SET created_at = ? WHERE id IN ?", old, []uint{persons[0].ID, persons[1].ID}).Error)
+	require.NoError(t, db.Delete(&models.Person{}, persons[1].ID).Error)
+	require.NoError(t, database.RecordAudit(db, models.AuditUpdate, &persons[0], &persons[0]))
+
+	var purgedIDs []uint
+	purged, err := database.PurgeCreatedBefore(context.Background(), db, time.Now().AddDate(0, 0, -30), func(ids []uint) {
+		purgedIDs = append(purgedIDs, ids...)
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+	assert.ElementsMatch(t, []uint{persons[0].ID, persons[1].ID}, purgedIDs)
+
+	var remaining []uint
+	seeded := []uint{persons[0].ID, persons[1].ID, persons[2].ID}
+	require.NoError(t, db.Unscoped().Model(&models.Person{}).Where("id IN ?", seeded).Order("id").Pluck("id", &remaining).Error)
+	assert.Equal(t, []uint{persons[2].ID}, remaining)
+
+	var audits int64
+	require.NoError(t, db.Raw("SELECT count(*) FROM audit WHERE person_id = ?", persons[0].ID).Scan(&audits).Error)
+	assert.Zero(t, audits)
+}
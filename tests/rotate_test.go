@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateExternalID(t *testing.T) {
+	cleanTestData()
+	person := seedPersons(t, "Test Rotate")[0]
+	oldID := person.ExternalID
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", fmt.Sprintf("/v1/persons/%d/rotate-external-id", person.ID), nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response models.PersonResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.NotEqual(t, oldID, response.ExternalID)
+	assert.Equal(t, uuid.Version(7), response.ExternalID.Version())
+	assert.Equal(t, "Test Rotate", response.Name)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/v1/persons/by-external/"+oldID.String(), nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/v1/persons/by-external/"+response.ExternalID.String(), nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	history := getHistory(t, person.ID)
+	require.Len(t, history, 1)
+	assert.Equal(t, models.AuditUpdate, history[0].Action)
+	assert.Contains(t, string(history[0].Before), oldID.String())
+	assert.Contains(t, string(history[0].After), response.ExternalID.String())
+}
+
+func TestRotateExternalIDNotFound(t *testing.T) {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/v1/persons/999999/rotate-external-id", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
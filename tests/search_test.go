@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func searchPersons(t *testing.T, query string) models.PersonListResponse {
+	t.Helper()
+
+	req := httptest.NewRequest("GET", "/v1/persons/search?"+query, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response models.PersonListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
+
+func seedSearchPerson(t *testing.T, name, email string) {
+	t.Helper()
+
+	person := models.Person{
+		TenantID:    testTenantID,
+		ExternalID:  uuid.New(),
+		Name:        name,
+		Email:       email,
+		DateOfBirth: models.DateOf(time.Date(1985, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	require.NoError(t, db.Create(&person).Error)
+}
+
+func searchNames(response models.PersonListResponse) []string {
+	names := make([]string, 0, len(response.Data))
+	for _, person := range response.Data {
+		names = append(names, person.Name)
+	}
+	return names
+}
+
+func TestSearchPersonsRanksBestMatchFirst(t *testing.T) {
+	cleanTestData()
+	seedSearchPerson(t, "Test Search Maria Lopez", "mlopez@example.com")
+	seedSearchPerson(t, "Test Search Maria Maria Garcia", "maria@example.com")
+	seedSearchPerson(t, "Test Search Peter Parker", "peter@example.com")
+
+	response := searchPersons(t, "q=maria")
+
+	assert.Equal(t, int64(2), response.Total)
+	assert.Equal(t, []string{"Test Search Maria Maria Garcia", "Test Search Maria Lopez"}, searchNames(response))
+}
+
+func TestSearchPersonsMatchesAllTerms(t *testing.T) {
+	cleanTestData()
+	seedSearchPerson(t, "Test Search Maria Lopez", "mlopez@example.com")
+	seedSearchPerson(t, "Test Search Maria Garcia", "mgarcia@example.com")
+
+	response := searchPersons(t, "q="+url.QueryEscape("maria garcia"))
+
+	assert.Equal(t, []string{"Test Search Maria Garcia"}, searchNames(response))
+}
+
+func TestSearchPersonsFallsBackToSubstringMatch(t *testing.T) {
+	cleanTestData()
+	seedSearchPerson(t, "Test Search Alexander", "alex@example.com")
+	seedSearchPerson(t, "Test Search Bob", "bob@example.com")
+
+	response := searchPersons(t, "q=xand")
+
+	assert.Equal(t, []string{"Test Search Alexander"}, searchNames(response))
+}
+
+func TestSearchPersonsIgnoresAccents(t *testing.T) {
+	cleanTestData()
+	seedSearchPerson(t, "Test Search José Álvarez", "jalvarez@example.com")
+	seedSearchPerson(t, "Test Search Joseph Bloggs", "jbloggs@example.com")
+
+	assert.Equal(t, []string{"Test Search José Álvarez"}, searchNames(searchPersons(t, "q=jose")))
+	assert.Equal(t, []string{"Test Search José Álvarez"}, searchNames(searchPersons(t, "q="+url.QueryEscape("ÁLVAREZ"))))
+}
+
+func TestSearchPersonsPaginates(t *testing.T) {
+	cleanTestData()
+	seedSearchPerson(t, "Test Search Page One", "one@example.com")
+	seedSearchPerson(t, "Test Search Page Two", "two@example.com")
+	seedSearchPerson(t, "Test Search Page Three", "three@example.com")
+
+	response := searchPersons(t, "q=page&page=2&page_size=2")
+
+	assert.Equal(t, int64(3), response.Total)
+	assert.Equal(t, 2, response.Page)
+	assert.Len(t, response.Data, 1)
+}
+
+func TestSearchPersonsRequiresQuery(t *testing.T) {
+	req := httptest.NewRequest("GET", "/v1/persons/search?q=%20", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
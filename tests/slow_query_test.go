@@ -0,0 +1,28 @@
+package tests
+
+import (
+	"bytes"
+	"log/slog"
+	"person-service/database"
+	"person-service/logger"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestSlowQueryIsLogged(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logger.NewWithWriter(&logs, slog.LevelInfo))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	slowDB := db.Session(&gorm.Session{Logger: database.NewLogger(20 * time.Millisecond)})
+	require.NoError(t, slowDB.Exec("SELECT pg_sleep(0.1)").Error)
+	require.NoError(t, slowDB.Exec("SELECT 1").Error)
+
+	assert.Equal(t, 1, bytes.Count(logs.Bytes(), []byte(`"msg":"Slow database query"`)), logs.String())
+	assert.Contains(t, logs.String(), "pg_sleep(0.1)")
+}
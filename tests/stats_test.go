@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPersonStats(t *testing.T) {
+	cleanTestData()
+
+	now := time.Now()
+	seeds := []struct {
+		dateOfBirth models.Date
+		createdAt   time.Time
+	}{
+		{models.NewDate(1975, time.March, 1), now.Add(-time.Hour)},
+		{models.NewDate(1978, time.July, 15), now.AddDate(0, 0, -3)},
+		{models.NewDate(1983, time.January, 20), now.AddDate(0, 0, -10)},
+		{models.NewDate(1995, time.December, 31), now.AddDate(0, 0, -45)},
+	}
+	var ageSum int
+	for i, seed := range seeds {
+		person := models.Person{
+			TenantID:    testTenantID,
+			ExternalID:  uuid.New(),
+			Name:        fmt.Sprintf("Test Stats %d", i),
+			Email:       fmt.Sprintf("teststats%d@example.com", i),
+			DateOfBirth: seed.dateOfBirth,
+			CreatedAt:   seed.createdAt,
+		}
+		require.NoError(t, db.Create(&person).Error)
+		ageSum += models.AgeAt(seed.dateOfBirth.Time(), now)
+	}
+	// Deleted persons and other tenants are not counted.
+	deleted := seedPersons(t, "Test Stats Deleted")[0]
+	require.NoError(t, db.Delete(&deleted).Error)
+	require.NoError(t, db.Create(&models.Person{
+		TenantID:    uuid.New(),
+		ExternalID:  uuid.New(),
+		Name:        "Test Stats Other Tenant",
+		Email:       "teststatsother@example.com",
+		DateOfBirth: models.NewDate(1960, time.May, 5),
+	}).Error)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/v1/persons/stats", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var stats models.PersonStatsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	assert.Equal(t, int64(4), stats.Total)
+	assert.Equal(t, []models.DecadeCount{
+		{Decade: 1970, Count: 2},
+		{Decade: 1980, Count: 1},
+		{Decade: 1990, Count: 1},
+	}, stats.ByDecade)
+	require.NotNil(t, stats.AverageAge)
+	assert.InDelta(t, float64(ageSum)/4, *stats.AverageAge, 0.05)
+	assert.Equal(t, int64(2), stats.CreatedLast7Days)
+	assert.Equal(t, int64(3), stats.CreatedLast30Days)
+}
+
+func TestGetPersonStatsEmpty(t *testing.T) {
+	cleanTestData()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/v1/persons/stats", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	assert.JSONEq(t, `{"total":0,"by_decade":[],"average_age":null,"created_last_7_days":0,"created_last_30_days":0}`, w.Body.String())
+}
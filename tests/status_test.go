@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setPersonStatus(t *testing.T, id uint, active bool) models.PersonResponse {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]bool{"active": active})
+	require.NoError(t, err)
+	req := httptest.NewRequest("PATCH", fmt.Sprintf("/v1/persons/%d/status", id), bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response models.PersonResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
+
+func getPersonStatus(path string) int {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+	return w.Code
+}
+
+func TestDeactivatedPersonIsHiddenUntilReactivated(t *testing.T) {
+	cleanTestData()
+	persons := seedPersons(t, "Test Status Kept", "Test Status Toggled")
+	toggled := persons[1]
+	assert.True(t, toggled.Active)
+
+	response := setPersonStatus(t, toggled.ID, false)
+	assert.False(t, response.Active)
+	assert.Equal(t, 1, response.Version)
+
+	listed := listPersons(t, "")
+	require.Len(t, listed.Data, 1)
+	assert.Equal(t, "Test Status Kept", listed.Data[0].Name)
+	assert.Equal(t, int64(1), listed.Total)
+	assert.Len(t, listPersons(t, "include_inactive=true").Data, 2)
+
+	assert.Equal(t, http.StatusNotFound, getPersonStatus(fmt.Sprintf("/v1/%d", toggled.ID)))
+	assert.Equal(t, http.StatusNotFound, getPersonStatus("/v1/persons/by-external/"+toggled.ExternalID.String()))
+	assert.Equal(t, http.StatusOK, getPersonStatus(fmt.Sprintf("/v1/%d?include_inactive=true", toggled.ID)))
+
+	response = setPersonStatus(t, toggled.ID, true)
+	assert.True(t, response.Active)
+
+	assert.Len(t, listPersons(t, "").Data, 2)
+	assert.Equal(t, http.StatusOK, getPersonStatus(fmt.Sprintf("/v1/%d", toggled.ID)))
+
+	history := getHistory(t, toggled.ID)
+	require.Len(t, history, 2)
+	assert.Contains(t, string(history[0].After), `"active":false`)
+	assert.Contains(t, string(history[1].After), `"active":true`)
+}
+
+func TestSetPersonStatusUnchangedIsNoop(t *testing.T) {
+	cleanTestData()
+	person := seedPersons(t, "Test Status Noop")[0]
+
+	response := setPersonStatus(t, person.ID, true)
+
+	assert.True(t, response.Active)
+	assert.Equal(t, 0, response.Version)
+	assert.Empty(t, getHistory(t, person.ID))
+}
+
+func TestSetPersonStatusRequiresActive(t *testing.T) {
+	cleanTestData()
+	person := seedPersons(t, "Test Status Missing")[0]
+
+	req := httptest.NewRequest("PATCH", fmt.Sprintf("/v1/persons/%d/status", person.ID), bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestSetPersonStatusNotFound(t *testing.T) {
+	req := httptest.NewRequest("PATCH", "/v1/persons/999999/status", bytes.NewReader([]byte(`{"active":false}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
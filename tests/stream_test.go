@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/events"
+	"person-service/middleware"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openStream subscribes to the person change stream as tenant and returns
+// the events it receives.
+func openStream(t *testing.T, server *httptest.Server, tenant uuid.UUID) <-chan events.PersonChange {
+	t.Helper()
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(streamCtx, "GET", server.URL+"/v1/persons/stream", nil)
+	require.NoError(t, err)
+	req.Header.Set(middleware.TenantHeader, tenant.String())
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	received := make(chan events.PersonChange, 16)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data:")
+			if !ok {
+				continue
+			}
+			var change events.PersonChange
+			if json.Unmarshal([]byte(data), &change) == nil {
+				received <- change
+			}
+		}
+	}()
+	return received
+}
+
+func TestStreamPersonChangesReceivesCreate(t *testing.T) {
+	cleanTestData()
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	received := openStream(t, server, testTenantID)
+	otherTenant := openStream(t, server, uuid.New())
+
+	person := seedPersons(t, "Test Stream")[0]
+
+	// Deletes from cleanTestData may still be arriving.
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case change := <-received:
+			if change.ExternalID != person.ExternalID {
+				continue
+			}
+			assert.Equal(t, events.PersonCreated, change.Event)
+			assert.Equal(t, person.ID, change.ID)
+		case <-timeout:
+			t.Fatal("no person change received")
+		}
+		break
+	}
+
+	select {
+	case change := <-otherTenant:
+		t.Fatalf("other tenant received %+v", change)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"person-service/middleware"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tenantRequest(t *testing.T, r *gin.Engine, tenant uuid.UUID, method, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var jsonBody []byte
+	if body != nil {
+		var err error
+		jsonBody, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest(method, path, bytes.NewReader(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.TenantHeader, tenant.String())
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func saveForTenant(t *testing.T, tenant uuid.UUID, reqBody models.SavePersonRequest) models.PersonResponse {
+	t.Helper()
+
+	w := tenantRequest(t, router, tenant, "POST", "/v1/save", reqBody)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var response models.PersonResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
+
+func TestTenantCannotAccessAnotherTenantsPerson(t *testing.T) {
+	cleanTestData()
+	tenantA, tenantB := uuid.New(), uuid.New()
+
+	created := saveForTenant(t, tenantA, models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Tenant A",
+		Email:       "testtenanta@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	var stored models.Person
+	require.NoError(t, db.Where("external_id = ?", created.ExternalID).First(&stored).Error)
+	assert.Equal(t, tenantA, stored.TenantID)
+
+	idPath := fmt.Sprintf("/v1/%d", stored.ID)
+	assert.Equal(t, http.StatusOK, tenantRequest(t, router, tenantA, "GET", idPath, nil).Code)
+
+	for _, path := range []string{idPath, "/v1/" + created.ExternalID.String(), "/v1/persons/by-external/" + created.ExternalID.String()} {
+		assert.Equal(t, http.StatusNotFound, tenantRequest(t, router, tenantB, "GET", path, nil).Code, path)
+	}
+
+	w := tenantRequest(t, router, tenantB, "GET", "/v1/persons", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var list models.PersonListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Zero(t, list.Total)
+	assert.Empty(t, list.Data)
+
+	update := models.UpdatePersonRequest{
+		Name:        "Test Tenant B Takeover",
+		Email:       "testtenanta@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	assert.Equal(t, http.StatusNotFound, tenantRequest(t, router, tenantB, "PUT", idPath, update).Code)
+	assert.Equal(t, http.StatusNotFound, tenantRequest(t, router, tenantB, "DELETE", idPath, nil).Code)
+
+	require.NoError(t, db.First(&stored, stored.ID).Error)
+	assert.Equal(t, "Test Tenant A", stored.Name)
+	assert.False(t, stored.DeletedAt.Valid)
+}
+
+func TestSameExternalIDAcrossTenants(t *testing.T) {
+	cleanTestData()
+	tenantA, tenantB := uuid.New(), uuid.New()
+	reqBody := models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Tenant Shared",
+		Email:       "testtenantshared@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+
+	saveForTenant(t, tenantA, reqBody)
+	saveForTenant(t, tenantB, reqBody)
+
+	// Within a tenant the external_id is still unique.
+	w := tenantRequest(t, router, tenantA, "POST", "/v1/save", reqBody)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var count int64
+	require.NoError(t, db.Model(&models.Person{}).Where("external_id = ?", reqBody.ExternalID).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestCachedPersonIsNotServedToAnotherTenant(t *testing.T) {
+	cleanTestData()
+	r := cachedRouter()
+	person := seedPersons(t, "Test Tenant Cached")[0]
+	path := fmt.Sprintf("/v1/%d", person.ID)
+
+	require.Equal(t, http.StatusOK, tenantRequest(t, r, testTenantID, "GET", path, nil).Code)
+
+	assert.Equal(t, http.StatusNotFound, tenantRequest(t, r, uuid.New(), "GET", path, nil).Code)
+}
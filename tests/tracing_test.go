@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"person-service/database"
+	"person-service/handlers"
+	"person-service/middleware"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestTracingCreatesRequestSpanWithDBChild(t *testing.T) {
+	cleanTestData()
+	person := seedPersons(t, "Test Traced")[0]
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	// A separate GORM instance on the same pool keeps the tracing callbacks
+	// away from the shared test database handle.
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	tracedDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, database.RegisterTracing(tracedDB, provider))
+
+	tracedRouter := gin.New()
+	tracedRouter.Use(middleware.Tracing(provider))
+	tracedRouter.GET("/:id", handlers.NewPersonHandler(tracedDB).GetPerson)
+
+	const parentTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest("GET", fmt.Sprintf("/%d", person.ID), nil)
+	req.Header.Set("traceparent", "00-"+parentTraceID+"-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+	tracedRouter.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	spans := exporter.GetSpans()
+	var serverSpan, dbSpan *tracetest.SpanStub
+	for i := range spans {
+		switch spans[i].SpanKind {
+		case trace.SpanKindServer:
+			serverSpan = &spans[i]
+		case trace.SpanKindClient:
+			dbSpan = &spans[i]
+		}
+	}
+	require.NotNil(t, serverSpan, "expected a server span")
+	require.NotNil(t, dbSpan, "expected a database span")
+
+	assert.Equal(t, "GET /:id", serverSpan.Name)
+	assert.Equal(t, parentTraceID, serverSpan.SpanContext.TraceID().String())
+	assert.Equal(t, "gorm.query", dbSpan.Name)
+	assert.Equal(t, serverSpan.SpanContext.SpanID(), dbSpan.Parent.SpanID())
+}
@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/database"
+	"person-service/handlers"
+	"person-service/middleware"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTransactionRouter mounts routes that write a person, register a side
+// effect counting into afterCommit and then answer with status, or panic
+// when status is 0, inside a request transaction.
+func newTransactionRouter(t *testing.T, externalID uuid.UUID, afterCommit *int) *gin.Engine {
+	t.Helper()
+
+	writeThen := func(status int) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			ctx := c.Request.Context()
+			person := models.Person{
+				ExternalID:  externalID,
+				Name:        "Test Transaction",
+				Email:       "testtransaction@example.com",
+				DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+			}
+			require.NoError(t, database.Conn(ctx, db).Create(&person).Error)
+			database.AfterCommit(ctx, func() { *afterCommit++ })
+			if status == 0 {
+				panic("handler failed after writing")
+			}
+			c.JSON(status, person.ToResponse())
+		}
+	}
+
+	r := gin.New()
+	r.Use(middleware.Recovery(), middleware.RequestID())
+	writes := r.Group("", defaultTestTenant, middleware.Tenant(), middleware.Transaction(db))
+	writes.POST("/ok", writeThen(http.StatusCreated))
+	writes.POST("/fail", writeThen(http.StatusInternalServerError))
+	writes.POST("/panic", writeThen(0))
+	writes.POST("/save", handlers.NewPersonHandler(db).SavePerson)
+	return r
+}
+
+func countByExternalID(t *testing.T, externalID uuid.UUID) int64 {
+	t.Helper()
+
+	var count int64
+	require.NoError(t, db.Model(&models.Person{}).Where("external_id = ?", externalID).Count(&count).Error)
+	return count
+}
+
+func TestTransactionCommitsOnSuccess(t *testing.T) {
+	cleanTestData()
+	externalID := uuid.New()
+	afterCommit := 0
+
+	w := httptest.NewRecorder()
+	newTransactionRouter(t, externalID, &afterCommit).ServeHTTP(w, httptest.NewRequest("POST", "/ok", nil))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), externalID.String())
+	assert.Equal(t, int64(1), countByExternalID(t, externalID))
+	assert.Equal(t, 1, afterCommit)
+}
+
+func TestTransactionRollsBackPartialWriteOnError(t *testing.T) {
+	cleanTestData()
+	externalID := uuid.New()
+	afterCommit := 0
+
+	w := httptest.NewRecorder()
+	newTransactionRouter(t, externalID, &afterCommit).ServeHTTP(w, httptest.NewRequest("POST", "/fail", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Zero(t, countByExternalID(t, externalID))
+	assert.Zero(t, afterCommit, "side effects of a rolled-back write must not run")
+}
+
+func TestTransactionRollsBackOnPanic(t *testing.T) {
+	cleanTestData()
+	externalID := uuid.New()
+	afterCommit := 0
+
+	w := httptest.NewRecorder()
+	newTransactionRouter(t, externalID, &afterCommit).ServeHTTP(w, httptest.NewRequest("POST", "/panic", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), middleware.InternalErrorMessage)
+	assert.Zero(t, countByExternalID(t, externalID))
+	assert.Zero(t, afterCommit)
+}
+
+func TestTransactionCoversHandlerWrites(t *testing.T) {
+	cleanTestData()
+	externalID := uuid.New()
+
+	jsonBody, err := json.Marshal(models.SavePersonRequest{
+		ExternalID:  externalID,
+		Name:        "Test Transaction Save",
+		Email:       "testtransactionsave@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	require.NoError(t, err)
+	afterCommit := 0
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/save", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	newTransactionRouter(t, uuid.New(), &afterCommit).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, int64(1), countByExternalID(t, externalID))
+}
@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/database"
+	"person-service/handlers"
+	"person-service/middleware"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// saveWithEmail posts a new person with the given email to /save on r.
+func saveWithEmail(t *testing.T, r http.Handler, email string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, err := json.Marshal(models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Unique Email",
+		Email:       email,
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest("POST", "/save", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// switchEmailUniqueness runs the statements the README gives for switching
+// UNIQUE_EMAIL.
+func switchEmailUniqueness(unique bool) error {
+	statements := []string{
+		"CREATE INDEX CONCURRENTLY idx_people_email_lookup ON people (tenant_id, email) WHERE deleted_at IS NULL",
+		"DROP INDEX CONCURRENTLY idx_people_email",
+	}
+	if unique {
+		statements = []string{
+			"CREATE UNIQUE INDEX CONCURRENTLY idx_people_email ON people (tenant_id, email) WHERE deleted_at IS NULL",
+			"DROP INDEX CONCURRENTLY idx_people_email_lookup",
+		}
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestUniqueEmailRejectsDuplicate(t *testing.T) {
+	cleanTestData()
+	require.NoError(t, database.CheckEmailUniqueness(ctx, db, true))
+
+	assert.Equal(t, http.StatusCreated, saveWithEmail(t, router, "testunique@example.com").Code)
+	w := saveWithEmail(t, router, "testunique@example.com")
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "Person with this email already exists")
+}
+
+func TestUniqueEmailDisabledAllowsDuplicates(t *testing.T) {
+	cleanTestData()
+	require.NoError(t, switchEmailUniqueness(false))
+	t.Cleanup(func() {
+		cleanTestData()
+		// The failed switch below leaves an invalid index behind.
+		require.NoError(t, db.Exec("DROP INDEX IF EXISTS idx_people_email").Error)
+		require.NoError(t, switchEmailUniqueness(true))
+	})
+	require.NoError(t, database.CheckEmailUniqueness(ctx, db, false))
+	err := database.CheckEmailUniqueness(ctx, db, true)
+	assert.ErrorIs(t, err, database.ErrEmailUniquenessMismatch)
+	assert.ErrorContains(t, err, "Switching email uniqueness")
+
+	h := handlers.NewPersonHandler(db, handlers.WithUniqueEmail(false))
+	r := gin.New()
+	r.POST("/save", defaultTestTenant, middleware.Tenant(), h.SavePerson)
+	r.POST("/save/batch", defaultTestTenant, middleware.Tenant(), h.SavePersonsBatch)
+
+	assert.Equal(t, http.StatusCreated, saveWithEmail(t, r, "testshared@example.com").Code)
+	assert.Equal(t, http.StatusCreated, saveWithEmail(t, r, "testshared@example.com").Code)
+
+	batch, err := json.Marshal([]models.SavePersonRequest{
+		{ExternalID: uuid.New(), Name: "Test Batch One", Email: "testshared@example.com", DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC))},
+		{ExternalID: uuid.New(), Name: "Test Batch Two", Email: "testshared@example.com", DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC))},
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest("POST", "/save/batch", bytes.NewReader(batch))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusMultiStatus, w.Code, w.Body.String())
+	var summary models.BatchSaveResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &summary))
+	assert.Equal(t, 2, summary.Created)
+
+	var count int64
+	require.NoError(t, db.Model(&models.Person{}).Where("email = ?", "testshared@example.com").Count(&count).Error)
+	assert.Equal(t, int64(4), count)
+
+	// Duplicates now present make enforcing uniqueness fail.
+	assert.ErrorContains(t, switchEmailUniqueness(true), "idx_people_email")
+}
@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func postSave(t *testing.T, path string, reqBody models.SavePersonRequest) (int, models.PersonResponse) {
+	t.Helper()
+
+	jsonBody, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", path, bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var response models.PersonResponse
+	if w.Code == http.StatusOK || w.Code == http.StatusCreated {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	}
+	return w.Code, response
+}
+
+func TestSavePersonUpsertCreatesNewPerson(t *testing.T) {
+	cleanTestData()
+
+	reqBody := models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Upsert New",
+		Email:       "testupsertnew@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+
+	code, response := postSave(t, "/v1/save?upsert=true", reqBody)
+
+	assert.Equal(t, http.StatusCreated, code)
+	assert.Equal(t, reqBody.ExternalID, response.ExternalID)
+	assert.Equal(t, 0, response.Version)
+}
+
+func TestSavePersonDuplicateWithoutUpsertConflicts(t *testing.T) {
+	cleanTestData()
+
+	reqBody := models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Upsert Conflict",
+		Email:       "testupsertconflict@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+	code, _ := postSave(t, "/v1/save", reqBody)
+	require.Equal(t, http.StatusCreated, code)
+
+	reqBody.Name = "Test Upsert Conflict Changed"
+	code, _ = postSave(t, "/v1/save", reqBody)
+	assert.Equal(t, http.StatusConflict, code)
+
+	var stored models.Person
+	require.NoError(t, db.Where("external_id = ?", reqBody.ExternalID).First(&stored).Error)
+	assert.Equal(t, "Test Upsert Conflict", stored.Name)
+}
+
+func TestSavePersonUpsertUpdatesExistingPerson(t *testing.T) {
+	cleanTestData()
+
+	reqBody := models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Upsert Before",
+		Email:       "testupsertbefore@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+	code, _ := postSave(t, "/v1/save", reqBody)
+	require.Equal(t, http.StatusCreated, code)
+
+	reqBody.Name = "Test Upsert After"
+	reqBody.LastName = "After"
+	reqBody.Email = "testupsertafter@example.com"
+	code, response := postSave(t, "/v1/save?upsert=true", reqBody)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Test Upsert After", response.Name)
+	assert.Equal(t, "testupsertafter@example.com", response.Email)
+	assert.Equal(t, 1, response.Version)
+
+	var stored []models.Person
+	require.NoError(t, db.Where("external_id = ?", reqBody.ExternalID).Find(&stored).Error)
+	require.Len(t, stored, 1)
+	assert.Equal(t, "Test Upsert After", stored[0].Name)
+	assert.Equal(t, "After", stored[0].LastName)
+	assert.Equal(t, "test upsert after", stored[0].SearchName)
+
+	history := getHistory(t, stored[0].ID)
+	require.Len(t, history, 2)
+	assert.Equal(t, models.AuditUpdate, history[1].Action)
+	assert.Contains(t, string(history[1].Before), "Test Upsert Before")
+}
@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPersonVCard(t *testing.T) {
+	cleanTestData()
+	person := seedPersons(t, "Test VCard")[0]
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/v1/persons/%d/vcard", person.ID), nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/vcard; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, fmt.Sprintf(`attachment; filename="%s.vcf"`, person.ExternalID), w.Header().Get("Content-Disposition"))
+	assert.Contains(t, w.Body.String(), "\r\nFN:Test VCard\r\n")
+	assert.Contains(t, w.Body.String(), "\r\nBDAY:19800101\r\n")
+	assert.Contains(t, w.Body.String(), "\r\nEMAIL:"+person.Email+"\r\n")
+}
+
+func TestGetPersonVCardByExternalIDNotFound(t *testing.T) {
+	cleanTestData()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/v1/persons/550e8400-e29b-41d4-a716-446655440000/vcard", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetPersonNegotiatesVCard(t *testing.T) {
+	cleanTestData()
+	person := seedPersons(t, "Test VCard Accept")[0]
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/v1/%d", person.ID), nil)
+	req.Header.Set("Accept", "text/vcard")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/vcard")
+	assert.Contains(t, w.Body.String(), "\r\nFN:Test VCard Accept\r\n")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/v1/%d", person.ID), nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+}
@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"person-service/events"
+	"person-service/handlers"
+	"person-service/middleware"
+	"person-service/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type webhookDelivery struct {
+	signature string
+	body      []byte
+}
+
+func TestSavePersonDeliversSignedWebhook(t *testing.T) {
+	cleanTestData()
+
+	deliveries := make(chan webhookDelivery, 1)
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		deliveries <- webhookDelivery{signature: r.Header.Get(events.SignatureHeader), body: body}
+	}))
+	defer subscriber.Close()
+
+	const secret = "test-webhook-secret"
+	dispatcher := events.NewWebhookDispatcher([]string{subscriber.URL}, secret)
+	defer dispatcher.Close()
+
+	webhookRouter := gin.New()
+	webhookRouter.POST("/save", defaultTestTenant, middleware.Tenant(), handlers.NewPersonHandler(db, handlers.WithNotifier(dispatcher)).SavePerson)
+
+	reqBody := models.SavePersonRequest{
+		ExternalID:  uuid.New(),
+		Name:        "Test Webhook",
+		Email:       "testwebhook@example.com",
+		DateOfBirth: models.DateOf(time.Date(1990, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+	jsonBody, err := json.Marshal(reqBody)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/save", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	webhookRouter.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var delivery webhookDelivery
+	select {
+	case delivery = <-deliveries:
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not delivered")
+	}
+
+	assert.Equal(t, events.Sign([]byte(secret), delivery.body), delivery.signature)
+	var payload events.WebhookPayload
+	require.NoError(t, json.Unmarshal(delivery.body, &payload))
+	assert.Equal(t, events.PersonCreated, payload.Event)
+	assert.Equal(t, reqBody.ExternalID, payload.Person.ExternalID)
+	assert.Equal(t, testTenantID, payload.Person.TenantID)
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"person-service/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getWithAccept(path, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", path, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetPersonReturnsXMLWhenAccepted(t *testing.T) {
+	cleanTestData()
+	person := seedPersons(t, "Test XML Person")[0]
+
+	w := getWithAccept(fmt.Sprintf("/v1/%d", person.ID), "application/xml")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/xml")
+	var response models.PersonResponse
+	require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "person", response.XMLName.Local)
+	assert.Equal(t, person.ExternalID, response.ExternalID)
+	assert.Equal(t, "Test XML Person", response.Name)
+}
+
+func TestGetPersonDefaultsToJSON(t *testing.T) {
+	cleanTestData()
+	person := seedPersons(t, "Test JSON Person")[0]
+
+	for _, accept := range []string{"", "application/json", "text/html"} {
+		w := getWithAccept(fmt.Sprintf("/v1/%d", person.ID), accept)
+
+		require.Equal(t, http.StatusOK, w.Code, accept)
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/json", accept)
+		var response models.PersonResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response), accept)
+		assert.Equal(t, "Test JSON Person", response.Name)
+	}
+}
+
+func TestListPersonsReturnsXMLWhenAccepted(t *testing.T) {
+	cleanTestData()
+	seedPersons(t, "Test XML A", "Test XML B")
+
+	w := getWithAccept("/v1/persons?sort=name", "application/xml")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/xml")
+	var response models.PersonListResponse
+	require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "persons", response.XMLName.Local)
+	assert.Equal(t, int64(2), response.Total)
+	require.Len(t, response.Data, 2)
+	assert.Equal(t, "Test XML A", response.Data[0].Name)
+
+	w = getWithAccept("/v1/persons?limit=1", "application/xml")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var cursorResponse models.PersonCursorResponse
+	require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &cursorResponse))
+	assert.Len(t, cursorResponse.Data, 1)
+	assert.NotEmpty(t, cursorResponse.NextCursor)
+}